@@ -13,8 +13,10 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
@@ -24,23 +26,72 @@ import (
 	"github.com/naqerl/browser-mcp-bridge/internal/server"
 )
 
-const defaultPort = 6277
+const (
+	defaultPort           = 6277
+	defaultConnectTimeout = 30 * time.Second
+	maxConnectTimeout     = 5 * time.Minute
+)
 
 // NativeMessage represents a message from/to the browser extension (legacy native messaging).
 type NativeMessage struct {
 	Port   int    `json:"port,omitempty"`
+	TLS    bool   `json:"tls,omitempty"`
 	Error  string `json:"error,omitempty"`
 	Status string `json:"status,omitempty"`
 }
 
 func main() {
+	os.Exit(run())
+}
+
+// run runs the host and returns the process exit code. Returning rather
+// than calling os.Exit lets the deferred cleanup, such as removing the PID
+// file and closing the log file, happen on every exit path.
+func run() int {
 	var (
 		port     = flag.Int("port", defaultPort, "WebSocket server port")
 		native   = flag.Bool("native", false, "Use native messaging mode (legacy)")
 		logLevel = flag.String("log-level", "info", "Log level (debug, info, warn, error)")
+		logFmt   = flag.String("log-format", "text", "Log format (text, json)")
+		logOut   = flag.String("log-output", "stderr", "Log destination: stderr or a file path to append to")
+		token    = flag.String("token", "", "Shared secret clients must present (Bearer header or ?token=)")
+		tlsCert  = flag.String("tls-cert", "", "TLS certificate file (enables HTTPS/WSS together with -tls-key)")
+		tlsKey   = flag.String("tls-key", "", "TLS private key file (enables HTTPS/WSS together with -tls-cert)")
+		metrics  = flag.Bool("metrics", true, "Expose Prometheus metrics at /metrics")
+		dedup    = flag.Bool("dedup", false, "Share in-flight extension requests with identical method and params")
+		pidFile  = flag.String("pid-file", "", "Write the process ID to this file while running")
+		bindAddr = flag.String("bind-address", "127.0.0.1", "Interface address to listen on")
+		pending  = flag.Int("pending-limit", 0, "Reject requests with 503 while this many extension requests are pending (0 = no limit)")
+		batch    = flag.Int("batch-concurrency", 4, "Number of browser_tabs_batch operations run at once")
+		retries  = flag.Int("retry-attempts", 1, "Attempts per extension request while the extension is disconnected (1 = no retries)")
+		backoff  = flag.Duration("retry-backoff", 500*time.Millisecond, "Delay before the first retry, doubled after each one")
+
+		connectTimeout = flag.Duration("connect-timeout", defaultConnectTimeout, "How long to wait for the extension to connect (max 5m)")
+		reconnect      = flag.Bool("reconnect", false, "Exit if the extension does not reconnect within -connect-timeout after a disconnect")
+		keepalive      = flag.Duration("keepalive-interval", 30*time.Second, "Interval between WebSocket pings; the connection is dropped after two missed intervals")
 	)
 	flag.Parse()
 
+	if *connectTimeout <= 0 || *connectTimeout > maxConnectTimeout {
+		fmt.Fprintf(os.Stderr, "invalid -connect-timeout %s: must be greater than 0 and at most %s\n", *connectTimeout, maxConnectTimeout)
+		return 2
+	}
+
+	if *keepalive <= 0 {
+		fmt.Fprintf(os.Stderr, "invalid -keepalive-interval %s: must be greater than 0\n", *keepalive)
+		return 2
+	}
+
+	if *logFmt != "text" && *logFmt != "json" {
+		fmt.Fprintf(os.Stderr, "invalid -log-format %q: must be text or json\n", *logFmt)
+		return 2
+	}
+
+	if (*tlsCert == "") != (*tlsKey == "") {
+		fmt.Fprintln(os.Stderr, "both -tls-cert and -tls-key must be provided to enable TLS")
+		return 2
+	}
+
 	// Setup logger
 	level := slog.LevelInfo
 	switch *logLevel {
@@ -51,9 +102,22 @@ func main() {
 	case "error":
 		level = slog.LevelError
 	}
-	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: level,
-	}))
+	var logWriter io.Writer = os.Stderr
+	if *logOut != "stderr" {
+		f, err := os.OpenFile(*logOut, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to open log output: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		logWriter = f
+	}
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	var logHandler slog.Handler = slog.NewTextHandler(logWriter, handlerOpts)
+	if *logFmt == "json" {
+		logHandler = slog.NewJSONHandler(logWriter, handlerOpts)
+	}
+	logger := slog.New(logHandler)
 
 	logger.Info("Browser MCP Bridge starting", "version", "1.0.0", "port", *port, "native", *native)
 
@@ -62,11 +126,28 @@ func main() {
 	var ctrl *browser.Controller
 
 	sender := &lazySender{logger: logger}
-	ctrl = browser.NewController(sender)
+	ctrl = browser.NewController(sender,
+		browser.WithLogger(logger),
+		browser.WithBatchConcurrency(*batch),
+		browser.WithRetry(*retries, *backoff),
+	)
 
-	srv = server.New(ctrl, logger)
+	srv = server.New(ctrl, logger,
+		server.WithToken(*token),
+		server.WithTLS(*tlsCert, *tlsKey),
+		server.WithBindAddress(*bindAddr),
+		server.WithMetrics(*metrics),
+		server.WithDedup(*dedup),
+		server.WithKeepaliveInterval(*keepalive),
+		server.WithPendingLimit(*pending),
+		server.WithNotificationHandler(server.LoggingNotificationHandler{Logger: logger}),
+	)
 	sender.server = srv
 
+	if !isLoopback(*bindAddr) {
+		logger.Warn("listening on a non-loopback address; the bridge is reachable from other hosts", "address", *bindAddr)
+	}
+
 	// Start WebSocket server on fixed port
 	actualPort, err := srv.StartFixed(*port)
 	if err != nil {
@@ -74,38 +155,40 @@ func main() {
 		if *native {
 			sendNativeMessage(NativeMessage{Error: err.Error()})
 		}
-		os.Exit(1)
+		return 1
 	}
 
-	logger.Info("WebSocket server started", "port", actualPort, "url", fmt.Sprintf("ws://localhost:%d/ws", actualPort))
+	if *pidFile != "" {
+		if err := writePIDFile(*pidFile); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write pid file: %v\n", err)
+			return 1
+		}
+		defer os.Remove(*pidFile)
+	}
+
+	wsScheme := "ws"
+	if srv.TLSEnabled() {
+		wsScheme = "wss"
+	}
+	wsURL := fmt.Sprintf("%s://%s/ws", wsScheme, net.JoinHostPort(urlHost(*bindAddr), strconv.Itoa(actualPort)))
+	logger.Info("WebSocket server started", "port", actualPort, "tls", srv.TLSEnabled(), "url", wsURL)
 
 	// If in native mode, communicate via native messaging
 	if *native {
 		// Send port to extension via native messaging
-		if err := sendNativeMessage(NativeMessage{Port: actualPort}); err != nil {
+		if err := sendNativeMessage(NativeMessage{Port: actualPort, TLS: srv.TLSEnabled()}); err != nil {
 			logger.Error("failed to send port to extension", "error", err)
-			os.Exit(1)
+			return 1
 		}
 
 		// Wait for extension to connect via WebSocket
-		logger.Info("waiting for extension connection...")
-		waitCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
-		for {
-			if srv.IsConnected() {
-				logger.Info("extension connected via WebSocket")
-				break
-			}
-			select {
-			case <-waitCtx.Done():
-				logger.Error("timeout waiting for extension connection")
-				sendNativeMessage(NativeMessage{Error: "timeout waiting for WebSocket connection"})
-				os.Exit(1)
-			case <-time.After(100 * time.Millisecond):
-				// Continue waiting
-			}
+		logger.Info("waiting for extension connection...", "timeout", *connectTimeout)
+		if !waitForConnection(srv, *connectTimeout) {
+			logger.Error("timeout waiting for extension connection")
+			sendNativeMessage(NativeMessage{Error: "timeout waiting for WebSocket connection"})
+			return 1
 		}
+		logger.Info("extension connected via WebSocket")
 
 		// Send ready status
 		sendNativeMessage(NativeMessage{Status: "ready"})
@@ -128,7 +211,7 @@ func main() {
 		}()
 	} else {
 		// Standalone mode - just wait for WebSocket connections
-		logger.Info("running in standalone mode", "url", fmt.Sprintf("ws://localhost:%d/ws", actualPort))
+		logger.Info("running in standalone mode", "url", wsURL)
 	}
 
 	// Handle shutdown gracefully (only on signal, not on disconnect)
@@ -146,6 +229,14 @@ func main() {
 			} else if !isConnected && wasConnected {
 				logger.Info("extension disconnected - waiting for reconnection")
 				wasConnected = false
+				if *reconnect {
+					if !waitForConnection(srv, *connectTimeout) {
+						logger.Error("extension did not reconnect", "timeout", *connectTimeout)
+						sigChan <- syscall.SIGTERM
+						return
+					}
+					continue
+				}
 			}
 			time.Sleep(500 * time.Millisecond)
 		}
@@ -154,6 +245,11 @@ func main() {
 	// Wait for shutdown signal only
 	<-sigChan
 	logger.Info("received shutdown signal")
+	// Remove the PID file before the graceful shutdown so that it does not
+	// point at a stopping process; the defer covers the error paths
+	if *pidFile != "" {
+		os.Remove(*pidFile)
+	}
 
 	// Graceful shutdown
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -164,6 +260,49 @@ func main() {
 	}
 
 	logger.Info("Browser MCP Bridge stopped")
+	return 0
+}
+
+// waitForConnection polls until the extension is connected or timeout
+// elapses, and reports whether it connected.
+func waitForConnection(srv *server.Server, timeout time.Duration) bool {
+	waitCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for {
+		if srv.IsConnected() {
+			return true
+		}
+		select {
+		case <-waitCtx.Done():
+			return false
+		case <-time.After(100 * time.Millisecond):
+			// Continue waiting
+		}
+	}
+}
+
+// isLoopback reports whether addr is a loopback host or IP address.
+func isLoopback(addr string) bool {
+	if addr == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.IsLoopback()
+}
+
+// urlHost returns the host to show in URLs for a bind address, replacing
+// unspecified addresses such as 0.0.0.0 with localhost.
+func urlHost(addr string) string {
+	if ip := net.ParseIP(addr); addr == "" || (ip != nil && ip.IsUnspecified()) {
+		return "localhost"
+	}
+	return addr
+}
+
+// writePIDFile writes the current process ID to path.
+func writePIDFile(path string) error {
+	return os.WriteFile(path, []byte(fmt.Sprintf("%d\n", os.Getpid())), 0o644)
 }
 
 // lazySender is a RequestSender that delegates to the server once it's ready.
@@ -179,7 +318,7 @@ func (l *lazySender) SendRequest(method string, params any) (*mcp.Message, error
 	l.mu.RUnlock()
 
 	if srv == nil {
-		return nil, fmt.Errorf("server not ready")
+		return nil, fmt.Errorf("server not ready: %w", mcp.ErrNotSent)
 	}
 	return srv.SendRequest(method, params)
 }
@@ -6,15 +6,56 @@ const RECONNECT_INTERVAL = 3000;
 
 // State
 let WS_PORT = DEFAULT_WS_PORT;
+let WS_TOKEN = '';
+let WS_TLS = false;
 let WS_URL = `ws://127.0.0.1:${WS_PORT}/ws`;
 
-// Update WebSocket URL when port changes
-function updateWsUrl(port) {
+// Update WebSocket URL when port, auth token or TLS mode changes
+function updateWsUrl(port, token = WS_TOKEN, tls = WS_TLS) {
   WS_PORT = port || DEFAULT_WS_PORT;
-  WS_URL = `ws://127.0.0.1:${WS_PORT}/ws`;
+  WS_TOKEN = token || '';
+  WS_TLS = !!tls;
+  WS_URL = `${WS_TLS ? 'wss' : 'ws'}://127.0.0.1:${WS_PORT}/ws`;
   return WS_URL;
 }
 
+// URL used to open the socket; carries the auth token (kept out of logs/popup)
+function wsConnectUrl() {
+  return WS_TOKEN ? `${WS_URL}?token=${encodeURIComponent(WS_TOKEN)}` : WS_URL;
+}
+
+// Methods handled by handleServerRequest, announced in extension/hello
+const SUPPORTED_METHODS = [
+  'browser.tabs.query',
+  'browser.tabs.update',
+  'browser.tabs.remove',
+  'browser.tabs.reload',
+  'browser.tabs.captureVisibleTab',
+  'browser.scripting.executeScript',
+  'browser.automation.getAccessibilityTree',
+  'browser.downloads.search',
+  'browser.downloads.open',
+  'browser.downloads.cancel',
+  'browser.downloads.erase',
+  'browser.dialog.getPending',
+  'browser.dialog.respond',
+  'browser.bookmarks.search',
+  'browser.bookmarks.create',
+  'browser.bookmarks.remove',
+  'browser.page.printToPDF',
+  'browser.webNavigation.getAllFrames',
+  'browser.geolocation.set',
+  'browser.geolocation.clear',
+  'browser.geolocation.track',
+  'browser.emulation.setMedia',
+  'browser.emulation.setColorScheme',
+  'browser.tabs.move',
+  'browser.windows.create',
+  'browser.lighthouse.run',
+  'browser.debugger.getTargets',
+  'browser.webRequest.getResponseHeaders'
+];
+
 // State
 const state = {
   ws: null,
@@ -89,9 +130,9 @@ async function init() {
   
   // Try to get port from storage (allows test configuration)
   try {
-    const stored = await chrome.storage.local.get(['wsPort']);
-    if (stored.wsPort) {
-      updateWsUrl(stored.wsPort);
+    const stored = await chrome.storage.local.get(['wsPort', 'wsToken', 'wsTls']);
+    if (stored.wsPort || stored.wsToken || stored.wsTls) {
+      updateWsUrl(stored.wsPort, stored.wsToken, stored.wsTls);
       log('log', 'Using stored WebSocket port:', WS_PORT);
     }
   } catch (e) {
@@ -113,7 +154,7 @@ function connectWebSocket() {
   log('log', 'Connecting to WebSocket at', WS_URL);
   
   try {
-    const ws = new WebSocket(WS_URL);
+    const ws = new WebSocket(wsConnectUrl());
     
     ws.onopen = () => {
       log('log', 'WebSocket connected');
@@ -125,6 +166,8 @@ function connectWebSocket() {
         clearTimeout(state.reconnectTimer);
         state.reconnectTimer = null;
       }
+      
+      sendHello();
     };
     
     ws.onmessage = (event) => {
@@ -200,6 +243,388 @@ function parseParams(params) {
   return params || {};
 }
 
+// Name of the browser the extension runs in, as reported in extension/hello
+function browserName() {
+  const ua = navigator.userAgent;
+  if (ua.includes('Firefox/')) return 'firefox';
+  if (ua.includes('Edg/')) return 'edge';
+  if (navigator.brave) return 'brave';
+  return 'chrome';
+}
+
+// Announce the extension version and capabilities to the host (notification)
+function sendHello() {
+  state.ws.send(JSON.stringify({
+    method: 'extension/hello',
+    params: {
+      version: chrome.runtime.getManifest().version,
+      browserName: browserName(),
+      supportedMethods: SUPPORTED_METHODS
+    }
+  }));
+}
+
+// Run a Chrome DevTools Protocol session against a tab.
+// Attaches the debugger, runs fn(send), and always detaches afterwards.
+async function withDebugger(tabId, fn) {
+  if (!chrome.debugger) {
+    const err = new Error('DevTools Protocol not available in this browser');
+    err.code = 'unsupported';
+    throw err;
+  }
+  const target = { tabId };
+  await chrome.debugger.attach(target, '1.3');
+  try {
+    return await fn((method, params = {}) => chrome.debugger.sendCommand(target, method, params));
+  } finally {
+    await chrome.debugger.detach(target).catch(() => {});
+  }
+}
+
+// Find the JavaScript dialog open in a tab, if any. Enabling the Page domain
+// makes Chrome re-announce a dialog that is already showing.
+function getPendingDialog(tabId, send) {
+  return new Promise((resolve, reject) => {
+    const onEvent = (source, method, params) => {
+      if (source.tabId === tabId && method === 'Page.javascriptDialogOpening') {
+        finish({ type: params.type, message: params.message, defaultPrompt: params.defaultPrompt || undefined });
+      }
+    };
+    const finish = (dialog) => {
+      chrome.debugger.onEvent.removeListener(onEvent);
+      clearTimeout(timer);
+      resolve(dialog);
+    };
+    const timer = setTimeout(() => finish(null), 250);
+    chrome.debugger.onEvent.addListener(onEvent);
+    send('Page.enable').catch(err => {
+      chrome.debugger.onEvent.removeListener(onEvent);
+      clearTimeout(timer);
+      reject(err);
+    });
+  });
+}
+
+// Geolocation overrides by tab ID, kept in session storage so they outlive
+// the service worker. An override is a track of positions played back every
+// intervalMs, looping; a fixed position is a track of one. While any
+// override exists, geolocation-hooks.js is registered to run at
+// document_start and each navigation hands it the tab's track.
+const GEOLOCATION_STORAGE_KEY = 'geolocationOverrides';
+const GEOLOCATION_SCRIPT_ID = 'mcp-geolocation-hooks';
+let geolocationUpdate = Promise.resolve();
+
+async function getGeolocationOverrides() {
+  const stored = await chrome.storage.session.get(GEOLOCATION_STORAGE_KEY);
+  return stored[GEOLOCATION_STORAGE_KEY] || {};
+}
+
+// Store or, with a null track, remove a tab's override. Updates are
+// serialized so concurrent calls do not overwrite each other.
+function setGeolocationOverride(tabId, track) {
+  geolocationUpdate = geolocationUpdate.catch(() => {}).then(async () => {
+    const overrides = await getGeolocationOverrides();
+    if (track) {
+      overrides[tabId] = track;
+    } else {
+      delete overrides[tabId];
+    }
+    await chrome.storage.session.set({ [GEOLOCATION_STORAGE_KEY]: overrides });
+
+    const wanted = Object.keys(overrides).length > 0;
+    const registered = await chrome.scripting.getRegisteredContentScripts({ ids: [GEOLOCATION_SCRIPT_ID] });
+    if (wanted && registered.length === 0) {
+      await chrome.scripting.registerContentScripts([{
+        id: GEOLOCATION_SCRIPT_ID,
+        matches: ['<all_urls>'],
+        js: ['geolocation-hooks.js'],
+        runAt: 'document_start',
+        world: 'MAIN',
+        persistAcrossSessions: false
+      }]);
+    } else if (!wanted && registered.length > 0) {
+      await chrome.scripting.unregisterContentScripts({ ids: [GEOLOCATION_SCRIPT_ID] });
+    }
+  });
+  return geolocationUpdate;
+}
+
+// Hand a tab's current document its track, or null to restore the native
+// API, installing the hooks first if the document predates them. Runs as
+// files and functions rather than eval so page CSP does not apply.
+async function applyGeolocationOverride(tabId, track) {
+  const target = { tabId };
+  await chrome.scripting.executeScript({
+    target,
+    world: 'MAIN',
+    injectImmediately: true,
+    files: ['geolocation-hooks.js']
+  });
+  await chrome.scripting.executeScript({
+    target,
+    world: 'MAIN',
+    injectImmediately: true,
+    func: (track) => { window.__mcpSetGeolocationTrack?.(track); },
+    args: [track]
+  });
+}
+
+chrome.webNavigation.onCommitted.addListener(async ({ tabId, frameId }) => {
+  if (frameId !== 0) return;
+  try {
+    const overrides = await getGeolocationOverrides();
+    if (Object.keys(overrides).length === 0) return;
+    // Tabs without an override are released at once rather than waiting
+    // for the hooks' fallback
+    await applyGeolocationOverride(tabId, overrides[tabId] || null);
+  } catch (err) {
+    log('warn', `Failed to re-apply geolocation override: ${err.message}`);
+  }
+});
+
+chrome.tabs.onRemoved.addListener((tabId) => {
+  setGeolocationOverride(tabId, null).catch(err => {
+    log('warn', `Failed to drop geolocation override: ${err.message}`);
+  });
+  mediaEmulations.delete(tabId);
+  documentHeaders.delete(tabId);
+  sendNotification('tab/removed', { tabId });
+});
+
+// Response headers of the latest top-level document request by tab ID.
+// Cleared when a navigation starts so headers never outlive their page;
+// after a redirect the final response's headers are kept.
+const documentHeaders = new Map();
+
+chrome.webRequest.onHeadersReceived.addListener(({ tabId, responseHeaders }) => {
+  if (tabId < 0) return;
+  const headers = {};
+  for (const { name, value } of responseHeaders || []) {
+    const key = name.toLowerCase();
+    headers[key] = key in headers ? `${headers[key]}, ${value}` : value;
+  }
+  documentHeaders.set(tabId, headers);
+}, { urls: ['<all_urls>'], types: ['main_frame'] },
+  // extraHeaders exposes headers such as Set-Cookie on Chrome
+  chrome.webRequest.OnHeadersReceivedOptions?.EXTRA_HEADERS
+    ? ['responseHeaders', 'extraHeaders']
+    : ['responseHeaders']);
+
+chrome.webNavigation.onBeforeNavigate.addListener(({ tabId, frameId }) => {
+  if (frameId === 0) documentHeaders.delete(tabId);
+});
+
+// Media emulation (CSS media type and prefers-color-scheme) by tab ID.
+// Detaching the debugger resets the emulation, so it stays attached to
+// these tabs until everything is reset.
+const mediaEmulations = new Map();
+
+async function setEmulatedMedia(tabId, changes) {
+  if (!chrome.debugger) {
+    const err = new Error('DevTools Protocol not available in this browser');
+    err.code = 'unsupported';
+    throw err;
+  }
+  const target = { tabId };
+  const attached = mediaEmulations.has(tabId);
+  const emulation = { media: '', colorScheme: '', ...mediaEmulations.get(tabId), ...changes };
+  if (!emulation.media && !emulation.colorScheme) {
+    if (mediaEmulations.delete(tabId)) {
+      await chrome.debugger.sendCommand(target, 'Emulation.setEmulatedMedia', { media: '', features: [] }).catch(() => {});
+      await chrome.debugger.detach(target).catch(() => {});
+    }
+    return;
+  }
+  if (!attached) {
+    await chrome.debugger.attach(target, '1.3');
+  }
+  mediaEmulations.set(tabId, emulation);
+  await chrome.debugger.sendCommand(target, 'Emulation.setEmulatedMedia', {
+    media: emulation.media,
+    features: [{ name: 'prefers-color-scheme', value: emulation.colorScheme }]
+  });
+}
+
+chrome.debugger?.onDetach.addListener(({ tabId }) => {
+  mediaEmulations.delete(tabId);
+  sendNotification('debugger/detached', { tabId });
+});
+
+// Convert the flat CDP AX node list into a nested tree, skipping ignored nodes
+function buildAccessibilityTree(nodes) {
+  const byId = new Map(nodes.map(n => [n.nodeId, n]));
+  const convert = (node) => {
+    const children = (node.childIds || [])
+      .map(id => byId.get(id))
+      .filter(Boolean)
+      .flatMap(convert);
+    if (node.ignored) return children;
+    const entry = {
+      role: node.role?.value || '',
+      name: node.name?.value || ''
+    };
+    if (node.description?.value) entry.description = node.description.value;
+    if (children.length) entry.children = children;
+    return [entry];
+  };
+  return nodes.length ? convert(nodes[0])[0] || { role: 'document', name: '' } : null;
+}
+
+// Page audit scored like Lighthouse. Lighthouse itself is a Node library
+// that cannot run in the extension, so the audits below are the subset of
+// its audits that can be computed from the loaded page: performance from
+// the page's paint and layout-shift timings, with the DevTools Protocol's
+// Performance metrics as diagnostics, and the other categories as the
+// share of their applicable checks that pass.
+const AUDIT_CATEGORIES = ['performance', 'accessibility', 'best-practices', 'seo'];
+
+async function runAudit(tabId, categories) {
+  const [{ result: page }] = await chrome.scripting.executeScript({
+    target: { tabId },
+    func: auditPage,
+    args: [categories]
+  });
+  const result = {
+    performance: 0,
+    accessibility: 0,
+    bestPractices: 0,
+    seo: 0,
+    diagnostics: { audits: page.audits }
+  };
+  if (categories.includes('performance')) {
+    result.diagnostics.metrics = await withDebugger(tabId, async (send) => {
+      await send('Performance.enable');
+      const { metrics } = await send('Performance.getMetrics');
+      return Object.fromEntries(metrics.map(m => [m.name, m.value]));
+    });
+    result.diagnostics.timings = page.timings;
+    result.performance = page.performance;
+  }
+  if (categories.includes('accessibility')) result.accessibility = page.accessibility;
+  if (categories.includes('best-practices')) result.bestPractices = page.bestPractices;
+  if (categories.includes('seo')) result.seo = page.seo;
+  return result;
+}
+
+// Runs in the page. Returns the category scores (0-100), the timings the
+// performance score is based on and the outcome of every audit.
+async function auditPage(categories) {
+  const entries = (type) => new Promise(resolve => {
+    if (!PerformanceObserver.supportedEntryTypes?.includes(type)) return resolve([]);
+    const observer = new PerformanceObserver(list => {
+      observer.disconnect();
+      resolve(list.getEntries());
+    });
+    observer.observe({ type, buffered: true });
+    setTimeout(() => {
+      observer.disconnect();
+      resolve([]);
+    }, 200);
+  });
+
+  // Lighthouse's log-normal metric scoring: a value at p10 scores 0.9 and
+  // one at the median 0.5
+  const erf = (x) => {
+    const sign = Math.sign(x);
+    x = Math.abs(x);
+    const t = 1 / (1 + 0.3275911 * x);
+    const y = t * (0.254829592 + t * (-0.284496736 + t * (1.421413741 + t * (-1.453152027 + t * 1.061405429))));
+    return sign * (1 - y * Math.exp(-x * x));
+  };
+  const metricScore = (value, p10, median) => {
+    if (value <= 0) return 1;
+    const x = Math.log(value / median) * 0.9061938024368232 / -Math.log(p10 / median);
+    return Math.min(1, Math.max(0, (1 - erf(x)) / 2));
+  };
+
+  const result = { audits: {}, timings: {} };
+
+  if (categories.includes('performance')) {
+    const [paints, lcps, shifts] = await Promise.all([entries('paint'), entries('largest-contentful-paint'), entries('layout-shift')]);
+    const fcp = paints.find(e => e.name === 'first-contentful-paint')?.startTime;
+    const lcp = lcps.at(-1)?.startTime ?? fcp;
+    const cls = shifts.filter(e => !e.hadRecentInput).reduce((sum, e) => sum + e.value, 0);
+    result.timings = { firstContentfulPaint: fcp ?? null, largestContentfulPaint: lcp ?? null, cumulativeLayoutShift: cls };
+    // Desktop curves and weights of Lighthouse 10; Speed Index and Total
+    // Blocking Time need a trace of the load and are left out
+    const weighted = [
+      [fcp, 934, 1600, 10],
+      [lcp, 1200, 2400, 25],
+      [cls, 0.1, 0.25, 25]
+    ].filter(([value]) => value !== undefined);
+    const weights = weighted.reduce((sum, [, , , weight]) => sum + weight, 0);
+    const score = weighted.reduce((sum, [value, p10, median, weight]) => sum + metricScore(value, p10, median) * weight, 0);
+    result.performance = weights ? Math.round(100 * score / weights) : 0;
+  }
+
+  const visible = (el) => el.checkVisibility ? el.checkVisibility() : el.getClientRects().length > 0;
+  const named = (el) => Boolean(el.getAttribute('aria-label')?.trim() || el.getAttribute('aria-labelledby') || el.title?.trim());
+  const count = (selector, failing) => Array.from(document.querySelectorAll(selector)).filter(failing).length;
+  const viewport = document.querySelector('meta[name="viewport"]')?.content || '';
+  const navigation = performance.getEntriesByType('navigation')[0];
+  const genericLinkText = ['click here', 'click', 'here', 'more', 'read more', 'learn more', 'link', 'go', 'start', 'this'];
+
+  // Each audit returns the number of failures, or null when it does not
+  // apply to the page
+  const audits = {
+    accessibility: {
+      'document-title': () => document.title.trim() ? 0 : 1,
+      'html-has-lang': () => document.documentElement.lang.trim() ? 0 : 1,
+      'image-alt': () => count('img', img => !img.hasAttribute('alt') && !named(img)),
+      'label': () => count('input:not([type=hidden]):not([type=submit]):not([type=button]):not([type=reset]):not([type=image]), select, textarea',
+        el => visible(el) && el.labels.length === 0 && !named(el) && !el.placeholder),
+      'button-name': () => count('button, [role=button]', el => visible(el) && !el.textContent.trim() && !named(el) && !el.querySelector('img[alt]:not([alt=""])')),
+      'link-name': () => count('a[href]', el => visible(el) && !el.textContent.trim() && !named(el) && !el.querySelector('img[alt]:not([alt=""])')),
+      'meta-viewport': () => viewport ? (/user-scalable\s*=\s*(no|0)|maximum-scale\s*=\s*(0|1|[0-4](\.\d+)?)\b/i.test(viewport) ? 1 : 0) : null
+    },
+    'best-practices': {
+      'is-on-https': () => location.protocol === 'https:' || ['localhost', '127.0.0.1', '[::1]'].includes(location.hostname) ? 0 : 1,
+      'doctype': () => document.doctype?.name.toLowerCase() === 'html' ? 0 : 1,
+      'charset': () => document.querySelector('meta[charset]') || document.querySelector('meta[http-equiv="Content-Type" i][content*="charset" i]') ? 0 : 1,
+      'image-aspect-ratio': () => count('img', img => {
+        if (!img.naturalWidth || !img.naturalHeight || !img.width || !img.height || getComputedStyle(img).objectFit !== 'fill') return false;
+        return Math.abs(img.width / img.height - img.naturalWidth / img.naturalHeight) > 0.05 * (img.naturalWidth / img.naturalHeight);
+      })
+    },
+    seo: {
+      'document-title': () => document.title.trim() ? 0 : 1,
+      'meta-description': () => document.querySelector('meta[name="description"]')?.content.trim() ? 0 : 1,
+      'viewport': () => /width\s*=/.test(viewport) ? 0 : 1,
+      'http-status-code': () => navigation?.responseStatus ? (navigation.responseStatus < 400 ? 0 : 1) : null,
+      'is-crawlable': () => count('meta[name="robots"], meta[name="googlebot"]', m => /noindex|none/i.test(m.content)),
+      'link-text': () => count('a[href]', a => genericLinkText.includes(a.textContent.trim().toLowerCase())),
+      'crawlable-anchors': () => count('a[href]', a => /^\s*javascript:/i.test(a.getAttribute('href'))),
+      'image-alt': () => count('img', img => !img.hasAttribute('alt')),
+      'canonical': () => {
+        const link = document.querySelector('link[rel="canonical"]');
+        if (!link) return null;
+        try {
+          new URL(link.getAttribute('href'));
+          return 0;
+        } catch (e) {
+          return 1;
+        }
+      }
+    }
+  };
+
+  const scoreKeys = { accessibility: 'accessibility', 'best-practices': 'bestPractices', seo: 'seo' };
+  for (const [category, checks] of Object.entries(audits)) {
+    if (!categories.includes(category)) continue;
+    result.audits[category] = {};
+    let applicable = 0, passed = 0;
+    for (const [id, check] of Object.entries(checks)) {
+      const failures = check();
+      if (failures === null) continue;
+      applicable++;
+      if (failures === 0) passed++;
+      result.audits[category][id] = { passed: failures === 0, failures };
+    }
+    result[scoreKeys[category]] = applicable ? Math.round(100 * passed / applicable) : 100;
+  }
+  return result;
+}
+
 // Handle requests from Go server (Go -> Extension)
 async function handleServerRequest(msg) {
   const operationId = `op-${Date.now()}-${Math.random().toString(36).substr(2, 9)}`;
@@ -215,7 +640,7 @@ async function handleServerRequest(msg) {
     
     switch (msg.method) {
       case 'browser.tabs.query':
-        result = await chrome.tabs.query({});
+        result = await chrome.tabs.query(params.queryInfo || {});
         break;
         
       case 'browser.tabs.update':
@@ -237,7 +662,7 @@ async function handleServerRequest(msg) {
         
       case 'browser.tabs.remove':
         try {
-          await chrome.tabs.remove(params.tabId);
+          await chrome.tabs.remove(params.tabIds || params.tabId);
           result = null;
         } catch (removeErr) {
           // Get available tabs to help LLM understand the situation
@@ -248,6 +673,11 @@ async function handleServerRequest(msg) {
         }
         break;
         
+      case 'browser.tabs.reload':
+        await chrome.tabs.reload(params.tabId, { bypassCache: !!params.bypassCache });
+        result = null;
+        break;
+        
       case 'browser.tabs.captureVisibleTab':
         result = await chrome.tabs.captureVisibleTab();
         break;
@@ -255,7 +685,10 @@ async function handleServerRequest(msg) {
       case 'browser.scripting.executeScript':
         try {
           result = await chrome.scripting.executeScript({
-            target: { tabId: params.tabId },
+            target: params.frameId !== undefined
+              ? { tabId: params.tabId, frameIds: [params.frameId] }
+              : { tabId: params.tabId },
+            world: params.world === 'MAIN' ? 'MAIN' : 'ISOLATED',
             func: (code) => {
               try {
                 return eval(code);
@@ -274,6 +707,147 @@ async function handleServerRequest(msg) {
         }
         break;
         
+      case 'browser.automation.getAccessibilityTree':
+        result = await withDebugger(params.tabId, async (send) => {
+          const { nodes } = await send('Accessibility.getFullAXTree');
+          return buildAccessibilityTree(nodes);
+        });
+        break;
+        
+      case 'browser.downloads.search': {
+        const query = { orderBy: ['-startTime'] };
+        if (params.query) query.query = [params.query];
+        if (params.limit > 0) query.limit = params.limit;
+        result = await chrome.downloads.search(query);
+        break;
+      }
+        
+      case 'browser.downloads.open':
+        await chrome.downloads.open(params.downloadId);
+        result = null;
+        break;
+        
+      case 'browser.downloads.cancel':
+        await chrome.downloads.cancel(params.downloadId);
+        result = null;
+        break;
+        
+      case 'browser.downloads.erase':
+        result = await chrome.downloads.erase({ id: params.downloadId });
+        break;
+        
+      case 'browser.dialog.getPending':
+        result = await withDebugger(params.tabId, (send) => getPendingDialog(params.tabId, send));
+        break;
+        
+      case 'browser.dialog.respond':
+        result = await withDebugger(params.tabId, async (send) => {
+          const dialog = await getPendingDialog(params.tabId, send);
+          if (!dialog) throw new Error('No JavaScript dialog is open in this tab');
+          await send('Page.handleJavaScriptDialog', {
+            accept: !!params.accept,
+            promptText: params.promptText || ''
+          });
+          return dialog;
+        });
+        break;
+        
+      case 'browser.bookmarks.search':
+        result = params.query
+          ? await chrome.bookmarks.search(params.query)
+          : await chrome.bookmarks.getTree();
+        break;
+        
+      case 'browser.bookmarks.create':
+        result = await chrome.bookmarks.create(params);
+        break;
+        
+      case 'browser.bookmarks.remove':
+        await chrome.bookmarks.remove(params.id);
+        result = null;
+        break;
+        
+      case 'browser.page.printToPDF':
+        result = await withDebugger(params.tabId, async (send) => {
+          const { data } = await send('Page.printToPDF', params.options || {});
+          return data;
+        });
+        break;
+        
+      case 'browser.webNavigation.getAllFrames':
+        result = await chrome.webNavigation.getAllFrames({ tabId: params.tabId });
+        break;
+        
+      case 'browser.geolocation.set': {
+        const track = {
+          positions: [{
+            latitude: params.latitude,
+            longitude: params.longitude,
+            accuracy: params.accuracy
+          }],
+          intervalMs: 0
+        };
+        await setGeolocationOverride(params.tabId, track);
+        await applyGeolocationOverride(params.tabId, track);
+        result = null;
+        break;
+      }
+        
+      case 'browser.geolocation.track': {
+        const track = { positions: params.positions, intervalMs: params.intervalMs };
+        await setGeolocationOverride(params.tabId, track);
+        await applyGeolocationOverride(params.tabId, track);
+        result = null;
+        break;
+      }
+        
+      case 'browser.geolocation.clear':
+        await setGeolocationOverride(params.tabId, null);
+        await applyGeolocationOverride(params.tabId, null);
+        result = null;
+        break;
+        
+      case 'browser.emulation.setMedia':
+        await setEmulatedMedia(params.tabId, { media: params.media || '' });
+        result = null;
+        break;
+        
+      case 'browser.emulation.setColorScheme':
+        await setEmulatedMedia(params.tabId, { colorScheme: params.scheme || '' });
+        result = null;
+        break;
+        
+      case 'browser.tabs.move':
+        result = await chrome.tabs.move(params.tabId, params.props);
+        break;
+        
+      case 'browser.windows.create':
+        result = await chrome.windows.create(params.createData);
+        break;
+        
+      case 'browser.lighthouse.run':
+        result = await runAudit(params.tabId, params.categories?.length ? params.categories : AUDIT_CATEGORIES);
+        break;
+        
+      case 'browser.debugger.getTargets': {
+        if (!chrome.debugger) {
+          const err = new Error('DevTools Protocol not available in this browser');
+          err.code = 'unsupported';
+          throw err;
+        }
+        result = await chrome.debugger.getTargets();
+        break;
+      }
+        
+      case 'browser.webRequest.getResponseHeaders': {
+        const headers = documentHeaders.get(params.tabId);
+        if (!headers) {
+          throw new Error(`No response headers recorded for tab ${params.tabId}; reload the page to capture them`);
+        }
+        result = headers;
+        break;
+      }
+        
       default:
         throw new Error(`Unknown method: ${msg.method}`);
     }
@@ -290,7 +864,7 @@ async function handleServerRequest(msg) {
       error: { 
         code: -32603, 
         message: err.message || String(err),
-        data: { method: msg.method, stack: err.stack }
+        data: { method: msg.method, code: err.code, stack: err.stack }
       } 
     };
     log('log', `Sending error response for ${msg.method}, id=${msg.id}:`, JSON.stringify(errorResponse));
@@ -311,6 +885,12 @@ function sendResponse(id, data) {
   state.ws.send(JSON.stringify(msg));
 }
 
+// Send notification to server (Extension -> Go); dropped while disconnected
+function sendNotification(method, params = {}) {
+  if (!state.ws || state.ws.readyState !== WebSocket.OPEN) return;
+  state.ws.send(JSON.stringify({ method, params }));
+}
+
 // Send request to server (Extension -> Go) and wait for response
 function sendRequest(method, params = {}) {
   return new Promise((resolve, reject) => {
@@ -350,6 +930,7 @@ const MCP = {
     connected: state.connected,
     ready: state.connected,
     wsPort: WS_PORT,
+    wsTls: WS_TLS,
     wsUrl: WS_URL,
     activeOperations: Array.from(state.activeOperations.values()),
     errors: state.errors.slice(-5),
@@ -357,12 +938,14 @@ const MCP = {
   }),
   
   // Reconnect function
-  reconnect: async (port) => {
-    if (port && port !== WS_PORT) {
-      updateWsUrl(port);
+  // tls is the host's reported TLS mode; the scheme sticks across restarts
+  reconnect: async (port, token, tls) => {
+    if ((port && port !== WS_PORT) || (token !== undefined && token !== WS_TOKEN) ||
+        (tls !== undefined && !!tls !== WS_TLS)) {
+      updateWsUrl(port || WS_PORT, token, tls === undefined ? WS_TLS : tls);
       // Save to storage for persistence
       try {
-        await chrome.storage.local.set({ wsPort: port });
+        await chrome.storage.local.set({ wsPort: WS_PORT, wsToken: WS_TOKEN, wsTls: WS_TLS });
         log('log', 'Port updated to', port);
       } catch (e) {
         // Storage may not be available in test environment
@@ -442,9 +1025,11 @@ chrome.runtime.onMessage.addListener((request, sender, sendResponse) => {
   }
   
   if (action === 'reconnect') {
-    // params is an array, first element is the port
+    // params is an array: [port, token, tls]
     const port = params && params[0];
-    MCP.reconnect(port).then(() => {
+    const token = params && params[1];
+    const tls = params && params[2];
+    MCP.reconnect(port, token, tls).then(() => {
       sendResponse({ success: true });
     }).catch(err => {
       sendResponse({ success: false, error: err.message });
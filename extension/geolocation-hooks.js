@@ -0,0 +1,118 @@
+// Browser MCP Bridge - Geolocation Hooks
+// Registered in the page's main world at document_start while any tab has a
+// geolocation override, so the override is in place before page scripts
+// run. The background script then calls window.__mcpSetGeolocationTrack with
+// the tab's track, or null to restore the native API. Calls made before that
+// are held back, and released to the native API if no track arrives.
+
+(() => {
+  if (window.__mcpSetGeolocationTrack) return;
+
+  const geo = navigator.geolocation;
+  if (!geo) return;
+
+  const RELEASE_TIMEOUT = 1000;
+  const native = {
+    getCurrentPosition: geo.getCurrentPosition.bind(geo),
+    watchPosition: geo.watchPosition.bind(geo),
+    clearWatch: geo.clearWatch.bind(geo)
+  };
+
+  // undefined until the background script decides, then a track or null
+  let track;
+  let index = 0;
+  let timer = null;
+  let pending = [];
+  let nextWatchId = 1;
+  // Watch IDs handed to the page, mapped to their success callback and,
+  // once released to the native API, the native watch ID
+  const watchers = new Map();
+
+  const position = () => {
+    const p = track.positions[index];
+    return {
+      coords: {
+        latitude: p.latitude,
+        longitude: p.longitude,
+        accuracy: p.accuracy,
+        altitude: p.altitude ?? null,
+        altitudeAccuracy: null,
+        heading: null,
+        speed: null
+      },
+      timestamp: Date.now()
+    };
+  };
+
+  const run = (call) => {
+    if (track) {
+      setTimeout(() => call.success(position()), 0);
+      return;
+    }
+    if (call.watchId) {
+      const watcher = watchers.get(call.watchId);
+      if (watcher) watcher.nativeId = native.watchPosition(call.success, call.error, call.options);
+    } else {
+      native.getCurrentPosition(call.success, call.error, call.options);
+    }
+  };
+
+  const setTrack = (next) => {
+    clearInterval(timer);
+    timer = null;
+    for (const watcher of watchers.values()) {
+      if (watcher.nativeId !== undefined) {
+        native.clearWatch(watcher.nativeId);
+        delete watcher.nativeId;
+      }
+    }
+    track = next;
+    index = 0;
+    if (track && track.positions.length > 1 && track.intervalMs > 0) {
+      timer = setInterval(() => {
+        index = (index + 1) % track.positions.length;
+        const pos = position();
+        watchers.forEach(watcher => watcher.success(pos));
+      }, track.intervalMs);
+    }
+
+    const calls = pending;
+    pending = null;
+    calls.forEach(run);
+    // Watches registered earlier follow the new source
+    for (const [watchId, watcher] of watchers) {
+      if (!calls.some(call => call.watchId === watchId)) run({ watchId, ...watcher });
+    }
+  };
+
+  const call = (entry) => {
+    if (pending) pending.push(entry);
+    else run(entry);
+  };
+
+  geo.getCurrentPosition = (success, error, options) => {
+    call({ success, error, options });
+  };
+  geo.watchPosition = (success, error, options) => {
+    const watchId = nextWatchId++;
+    watchers.set(watchId, { success, error, options });
+    call({ watchId, success, error, options });
+    return watchId;
+  };
+  geo.clearWatch = (watchId) => {
+    const watcher = watchers.get(watchId);
+    if (watcher && watcher.nativeId !== undefined) native.clearWatch(watcher.nativeId);
+    watchers.delete(watchId);
+  };
+
+  Object.defineProperty(window, '__mcpSetGeolocationTrack', {
+    value: (next) => {
+      if (!pending) pending = [];
+      setTrack(next);
+    }
+  });
+
+  setTimeout(() => {
+    if (track === undefined && pending) setTrack(null);
+  }, RELEASE_TIMEOUT);
+})();
@@ -0,0 +1,73 @@
+// Browser MCP Bridge - Page Hooks
+// Runs in the page's main world at document_start so data can be captured
+// before page scripts run. The host reads the buffers via executeScript.
+
+(() => {
+  if (window.__mcpHooksInstalled) return;
+  window.__mcpHooksInstalled = true;
+
+  const MAX_ENTRIES = 500;
+
+  function push(buffer, entry) {
+    buffer.push(entry);
+    if (buffer.length > MAX_ENTRIES) buffer.shift();
+  }
+
+  function stringify(value) {
+    if (typeof value === 'string') return value;
+    if (value instanceof Error) return value.stack || value.message;
+    try {
+      return JSON.stringify(value);
+    } catch (e) {
+      return String(value);
+    }
+  }
+
+  // Caller location ("url:line:col") taken from the stack of a hook frame
+  function callerSource() {
+    const lines = (new Error().stack || '').split('\n');
+    const frame = lines[3] || '';
+    const match = frame.match(/\(?([^()\s]+:\d+:\d+)\)?\s*$/);
+    return match ? match[1] : '';
+  }
+
+  // --- Console capture ---
+  window.__mcpConsoleLogs = [];
+  for (const level of ['log', 'info', 'warn', 'error', 'debug']) {
+    const original = console[level];
+    console[level] = function (...args) {
+      try {
+        push(window.__mcpConsoleLogs, {
+          level,
+          message: args.map(stringify).join(' '),
+          timestamp: Date.now(),
+          source: callerSource()
+        });
+      } catch (e) {
+        // Never break the page's own logging
+      }
+      return original.apply(this, args);
+    };
+  }
+
+  // --- Uncaught errors ---
+  window.__mcpPageErrors = [];
+  window.addEventListener('error', (event) => {
+    push(window.__mcpPageErrors, {
+      message: event.message || stringify(event.error),
+      source: event.filename || '',
+      lineno: event.lineno || 0,
+      colno: event.colno || 0,
+      timestamp: Date.now()
+    });
+  });
+  window.addEventListener('unhandledrejection', (event) => {
+    push(window.__mcpPageErrors, {
+      message: 'Unhandled rejection: ' + stringify(event.reason),
+      source: '',
+      lineno: 0,
+      colno: 0,
+      timestamp: Date.now()
+    });
+  });
+})();
@@ -0,0 +1,70 @@
+// Package browser implements bookmark management via the extension.
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// ListBookmarks returns bookmarks matching query. An empty query returns the
+// full bookmark tree.
+func (c *Controller) ListBookmarks(ctx context.Context, query string) ([]mcp.Bookmark, error) {
+	resp, err := c.send(ctx, "browser.bookmarks.search", map[string]any{
+		"query": query,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	var bookmarks []mcp.Bookmark
+	if err := json.Unmarshal(resp.Result, &bookmarks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bookmarks: %w", err)
+	}
+	return bookmarks, nil
+}
+
+// CreateBookmark creates a bookmark under parentID. An empty url creates a
+// folder; an empty parentID uses the browser's default folder.
+func (c *Controller) CreateBookmark(ctx context.Context, parentID, title, url string) (mcp.Bookmark, error) {
+	props := map[string]any{"title": title}
+	if parentID != "" {
+		props["parentId"] = parentID
+	}
+	if url != "" {
+		props["url"] = url
+	}
+
+	resp, err := c.send(ctx, "browser.bookmarks.create", props)
+	if err != nil {
+		return mcp.Bookmark{}, err
+	}
+	if resp.Error != nil {
+		return mcp.Bookmark{}, resp.Error
+	}
+
+	var bookmark mcp.Bookmark
+	if err := json.Unmarshal(resp.Result, &bookmark); err != nil {
+		return mcp.Bookmark{}, fmt.Errorf("failed to unmarshal bookmark: %w", err)
+	}
+	return bookmark, nil
+}
+
+// DeleteBookmark removes a bookmark or an empty folder.
+func (c *Controller) DeleteBookmark(ctx context.Context, id string) error {
+	resp, err := c.send(ctx, "browser.bookmarks.remove", map[string]any{
+		"id": id,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return nil
+}
@@ -7,29 +7,72 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/naqerl/browser-mcp-bridge/internal/convert"
+	"github.com/naqerl/browser-mcp-bridge/internal/logging"
 	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
 )
 
 // Controller implements the server.Handler interface by forwarding
 // requests to the browser extension via WebSocket.
 type Controller struct {
-	sender RequestSender
+	sender           RequestSender
+	batchConcurrency int
+	screenshotMu     sync.Mutex
+	snippets         *SnippetRegistry
+	retryAttempts    int
+	retryBackoff     time.Duration
+	logger           *slog.Logger
+
+	emulationMu sync.Mutex
+	emulations  map[int]map[string]string
+
+	captureMu sync.Mutex
+	capture   *cachedCapture
 }
 
+// defaultBatchConcurrency is the number of batched tab operations run at once.
+const defaultBatchConcurrency = 4
+
 // RequestSender sends requests to the extension and returns responses.
 type RequestSender interface {
 	SendRequest(method string, params any) (*mcp.Message, error)
 }
 
+// Option configures optional Controller behaviour.
+type Option func(*Controller)
+
+// WithBatchConcurrency limits how many batched tab operations run at once.
+func WithBatchConcurrency(n int) Option {
+	return func(c *Controller) {
+		if n > 0 {
+			c.batchConcurrency = n
+		}
+	}
+}
+
 // NewController creates a new browser controller.
-func NewController(sender RequestSender) *Controller {
-	return &Controller{sender: sender}
+func NewController(sender RequestSender, opts ...Option) *Controller {
+	c := &Controller{
+		sender:           sender,
+		batchConcurrency: defaultBatchConcurrency,
+		snippets:         NewSnippetRegistry(),
+		logger:           slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // ListTabs returns all open tabs.
 func (c *Controller) ListTabs(ctx context.Context) ([]mcp.Tab, error) {
-	resp, err := c.sender.SendRequest("browser.tabs.query", map[string]any{})
+	resp, err := c.send(ctx, "browser.tabs.query", map[string]any{})
 	if err != nil {
 		return nil, err
 	}
@@ -44,9 +87,61 @@ func (c *Controller) ListTabs(ctx context.Context) ([]mcp.Tab, error) {
 	return tabs, nil
 }
 
+// FilterTabs returns the tabs matching filter. Status, active, pinned and
+// audible are filtered by the browser; URL and title are matched here.
+func (c *Controller) FilterTabs(ctx context.Context, filter mcp.TabFilter) ([]mcp.Tab, error) {
+	query := map[string]any{}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.Active != nil {
+		query["active"] = *filter.Active
+	}
+	if filter.Pinned != nil {
+		query["pinned"] = *filter.Pinned
+	}
+	if filter.Audible != nil {
+		query["audible"] = *filter.Audible
+	}
+
+	resp, err := c.send(ctx, "browser.tabs.query", map[string]any{
+		"queryInfo": query,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	var tabs []mcp.Tab
+	if err := json.Unmarshal(resp.Result, &tabs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tabs: %w", err)
+	}
+
+	title := strings.ToLower(filter.TitleContains)
+	matched := []mcp.Tab{}
+	for _, tab := range tabs {
+		if filter.URLPattern != "" {
+			ok, err := path.Match(filter.URLPattern, tab.URL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid urlPattern %q: %w", filter.URLPattern, err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		if title != "" && !strings.Contains(strings.ToLower(tab.Title), title) {
+			continue
+		}
+		matched = append(matched, tab)
+	}
+	return matched, nil
+}
+
 // ActivateTab focuses a specific tab.
 func (c *Controller) ActivateTab(ctx context.Context, tabID int) error {
-	resp, err := c.sender.SendRequest("browser.tabs.update", map[string]any{
+	resp, err := c.send(ctx, "browser.tabs.update", map[string]any{
 		"tabId": tabID,
 		"props": map[string]any{"active": true},
 	})
@@ -61,7 +156,8 @@ func (c *Controller) ActivateTab(ctx context.Context, tabID int) error {
 
 // NavigateTab navigates a tab to a URL.
 func (c *Controller) NavigateTab(ctx context.Context, tabID int, url string) error {
-	resp, err := c.sender.SendRequest("browser.tabs.update", map[string]any{
+	logging.LoggerFromContext(ctx).Debug("navigating tab", "tabId", tabID, "url", url)
+	resp, err := c.send(ctx, "browser.tabs.update", map[string]any{
 		"tabId": tabID,
 		"props": map[string]any{"url": url},
 	})
@@ -74,10 +170,131 @@ func (c *Controller) NavigateTab(ctx context.Context, tabID int, url string) err
 	return nil
 }
 
+// MuteTab mutes or unmutes a tab.
+func (c *Controller) MuteTab(ctx context.Context, tabID int, muted bool) error {
+	resp, err := c.send(ctx, "browser.tabs.update", map[string]any{
+		"tabId": tabID,
+		"props": map[string]any{"muted": muted},
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return nil
+}
+
 // CloseTab closes a tab.
 func (c *Controller) CloseTab(ctx context.Context, tabID int) error {
-	resp, err := c.sender.SendRequest("browser.tabs.remove", map[string]any{
+	resp, err := c.send(ctx, "browser.tabs.remove", map[string]any{
+		"tabId": tabID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return nil
+}
+
+// CloseMultipleTabs closes several tabs in a single request.
+func (c *Controller) CloseMultipleTabs(ctx context.Context, tabIDs []int) error {
+	if len(tabIDs) == 0 {
+		return fmt.Errorf("tabIds must not be empty")
+	}
+
+	resp, err := c.send(ctx, "browser.tabs.remove", map[string]any{
+		"tabIds": tabIDs,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return nil
+}
+
+// CloseOtherTabs closes every tab except keepTabID and returns the number
+// of tabs closed.
+func (c *Controller) CloseOtherTabs(ctx context.Context, keepTabID int) (int, error) {
+	tabs, err := c.ListTabs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	found := false
+	var others []int
+	for _, tab := range tabs {
+		if tab.ID == keepTabID {
+			found = true
+			continue
+		}
+		others = append(others, tab.ID)
+	}
+	if !found {
+		return 0, fmt.Errorf("tab %d not found", keepTabID)
+	}
+	if len(others) == 0 {
+		return 0, nil
+	}
+	if err := c.CloseMultipleTabs(ctx, others); err != nil {
+		return 0, err
+	}
+	return len(others), nil
+}
+
+// GetResponseHeaders returns the HTTP response headers of the document
+// loaded in a tab, with lowercase names and repeated headers joined by
+// ", ". The extension records headers as documents load, so pages loaded
+// before it started, or served from the back/forward cache, have none
+// until reloaded.
+func (c *Controller) GetResponseHeaders(ctx context.Context, tabID int) (map[string]string, error) {
+	resp, err := c.send(ctx, "browser.webRequest.getResponseHeaders", map[string]any{
+		"tabId": tabID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, responseError(resp.Error)
+	}
+
+	var headers map[string]string
+	if err := json.Unmarshal(resp.Result, &headers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response headers: %w", err)
+	}
+	return headers, nil
+}
+
+// ReloadTab reloads a tab, optionally bypassing the cache. It returns once
+// the reload has started; use WaitForNavigation to wait for it to finish.
+func (c *Controller) ReloadTab(ctx context.Context, tabID int, bypassCache bool) error {
+	resp, err := c.send(ctx, "browser.tabs.reload", map[string]any{
+		"tabId":       tabID,
+		"bypassCache": bypassCache,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return nil
+}
+
+// MoveTab moves a tab to position index (-1 for the end) in window windowID,
+// or within its current window when windowID is 0. The tab is not reloaded.
+func (c *Controller) MoveTab(ctx context.Context, tabID int, windowID int, index int) error {
+	props := map[string]any{"index": index}
+	if windowID != 0 {
+		props["windowId"] = windowID
+	}
+	resp, err := c.send(ctx, "browser.tabs.move", map[string]any{
 		"tabId": tabID,
+		"props": props,
 	})
 	if err != nil {
 		return err
@@ -88,14 +305,38 @@ func (c *Controller) CloseTab(ctx context.Context, tabID int) error {
 	return nil
 }
 
+// MoveTabToNewWindow moves a tab into a new window of its own without
+// reloading it, and returns the new window.
+func (c *Controller) MoveTabToNewWindow(ctx context.Context, tabID int) (mcp.Window, error) {
+	resp, err := c.send(ctx, "browser.windows.create", map[string]any{
+		"createData": map[string]any{"tabId": tabID},
+	})
+	if err != nil {
+		return mcp.Window{}, err
+	}
+	if resp.Error != nil {
+		return mcp.Window{}, resp.Error
+	}
+
+	var window mcp.Window
+	if err := json.Unmarshal(resp.Result, &window); err != nil {
+		return mcp.Window{}, fmt.Errorf("failed to unmarshal window: %w", err)
+	}
+	return window, nil
+}
+
 // ScreenshotTab takes a screenshot of a tab.
 func (c *Controller) ScreenshotTab(ctx context.Context, tabID int) (string, error) {
+	// Capturing requires the tab to be visible, so screenshots cannot overlap
+	c.screenshotMu.Lock()
+	defer c.screenshotMu.Unlock()
+
 	// First activate the tab
 	if err := c.ActivateTab(ctx, tabID); err != nil {
 		return "", err
 	}
 
-	resp, err := c.sender.SendRequest("browser.tabs.captureVisibleTab", map[string]any{})
+	resp, err := c.send(ctx, "browser.tabs.captureVisibleTab", map[string]any{})
 	if err != nil {
 		return "", err
 	}
@@ -110,6 +351,27 @@ func (c *Controller) ScreenshotTab(ctx context.Context, tabID int) (string, erro
 	return dataURL, nil
 }
 
+// PrintToPDF exports a tab's page as a base64-encoded PDF using the
+// DevTools Protocol. It returns ErrUnsupported where that is unavailable.
+func (c *Controller) PrintToPDF(ctx context.Context, tabID int, opts mcp.PDFOptions) (string, error) {
+	resp, err := c.send(ctx, "browser.page.printToPDF", map[string]any{
+		"tabId":   tabID,
+		"options": opts,
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.Error != nil {
+		return "", responseError(resp.Error)
+	}
+
+	var data string
+	if err := json.Unmarshal(resp.Result, &data); err != nil {
+		return "", fmt.Errorf("failed to unmarshal PDF: %w", err)
+	}
+	return data, nil
+}
+
 // GetPageContent extracts page content from a tab.
 func (c *Controller) GetPageContent(ctx context.Context, tabID int) (*mcp.PageContent, error) {
 	script := `
@@ -139,12 +401,290 @@ func (c *Controller) GetPageContent(ctx context.Context, tabID int) (*mcp.PageCo
 	return content, nil
 }
 
+// GetLinks returns the page's distinct links matching filter, in document
+// order.
+func (c *Controller) GetLinks(ctx context.Context, tabID int, filter mcp.LinkFilter) ([]mcp.Link, error) {
+	script := `
+		Array.from(document.querySelectorAll('a[href]'), a => ({
+			text: a.innerText.trim(),
+			href: a.href
+		}))
+	`
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []mcp.Link
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal links: %w", err)
+	}
+
+	text := strings.ToLower(filter.TextContains)
+	seen := make(map[string]bool)
+	links := []mcp.Link{}
+	for _, link := range all {
+		if filter.BaseURLOnly {
+			link.Href, _, _ = strings.Cut(link.Href, "#")
+			link.Href, _, _ = strings.Cut(link.Href, "?")
+		}
+		if seen[link.Href] {
+			continue
+		}
+		if filter.URLPattern != "" {
+			ok, err := path.Match(filter.URLPattern, link.Href)
+			if err != nil {
+				return nil, fmt.Errorf("invalid urlPattern %q: %w", filter.URLPattern, err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		if text != "" && !strings.Contains(strings.ToLower(link.Text), text) {
+			continue
+		}
+		seen[link.Href] = true
+		links = append(links, link)
+		if filter.Limit > 0 && len(links) == filter.Limit {
+			break
+		}
+	}
+	return links, nil
+}
+
+// GetAnchorByText returns the first link whose trimmed text content equals
+// text, or contains it when exact is false. Returns ErrNotFound if no link
+// matches.
+func (c *Controller) GetAnchorByText(ctx context.Context, tabID int, text string, exact bool) (*mcp.Link, error) {
+	result, err := c.ExecuteScript(ctx, tabID, anchorByTextJS(text, exact, false))
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	var link mcp.Link
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, &link); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal link: %w", err)
+	}
+	return &link, nil
+}
+
+// ClickAnchorByText clicks the first link matched as by GetAnchorByText.
+func (c *Controller) ClickAnchorByText(ctx context.Context, tabID int, text string, exact bool) error {
+	result, err := c.ExecuteScript(ctx, tabID, anchorByTextJS(text, exact, true))
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// anchorByTextJS returns a script that finds the first anchor matching text
+// and reports it as a Link, clicking it first if click is set.
+func anchorByTextJS(text string, exact, click bool) string {
+	return fmt.Sprintf(`
+		(() => {
+			const text = %q, exact = %t;
+			const el = Array.from(document.querySelectorAll('a')).find(a => {
+				const content = a.textContent.trim();
+				return exact ? content === text : content.includes(text);
+			});
+			if (!el) return { error: 'No link with text ' + JSON.stringify(text), code: 'not_found' };
+			if (%t) el.click();
+			return { text: el.textContent.trim(), href: el.href };
+		})()
+	`, text, exact, click)
+}
+
+// GetPageSource returns the page's original HTML as served, before any
+// script modified the DOM. The document URL is re-fetched from the page,
+// preferring the HTTP cache, so pages produced by a POST may differ.
+func (c *Controller) GetPageSource(ctx context.Context, tabID int) (string, error) {
+	script := `
+		fetch(window.location.href, { cache: 'force-cache', credentials: 'include' })
+			.then(response => response.text())
+			.then(source => ({ source }))
+			.catch(e => ({ error: 'Failed to fetch page source: ' + e.message }))
+	`
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return "", err
+	}
+	if err := resultError(result); err != nil {
+		return "", err
+	}
+
+	m, _ := result.(map[string]any)
+	source, _ := m["source"].(string)
+	return source, nil
+}
+
+// GetPageContentMarkdown returns the page's HTML converted to Markdown.
+func (c *Controller) GetPageContentMarkdown(ctx context.Context, tabID int) (string, error) {
+	content, err := c.GetPageContent(ctx, tabID)
+	if err != nil {
+		return "", err
+	}
+	return convert.HTMLToMarkdown(content.HTML, content.URL), nil
+}
+
+// GetTitle returns the title of the page in a tab.
+func (c *Controller) GetTitle(ctx context.Context, tabID int) (string, error) {
+	result, err := c.ExecuteScript(ctx, tabID, "document.title")
+	if err != nil {
+		return "", err
+	}
+	title, _ := result.(string)
+	return title, nil
+}
+
+// GetCurrentURL returns the URL of the page in a tab.
+func (c *Controller) GetCurrentURL(ctx context.Context, tabID int) (string, error) {
+	result, err := c.ExecuteScript(ctx, tabID, "window.location.href")
+	if err != nil {
+		return "", err
+	}
+	url, _ := result.(string)
+	return url, nil
+}
+
 // ExecuteScript runs JavaScript in a tab.
 func (c *Controller) ExecuteScript(ctx context.Context, tabID int, script string) (any, error) {
-	resp, err := c.sender.SendRequest("browser.scripting.executeScript", map[string]any{
+	return c.executeScriptInWorld(ctx, tabID, script, "")
+}
+
+// maxAsyncScriptTimeout keeps async scripts within the 30s extension
+// request timeout.
+const maxAsyncScriptTimeout = 25000
+
+// ExecuteAsyncScript runs script in the page's main world as the body of a
+// function whose last argument is a done callback, and returns the value
+// passed to it. Returns ErrTimeout if done is not called within timeoutMs
+// (default 5000, at most 25000).
+func (c *Controller) ExecuteAsyncScript(ctx context.Context, tabID int, script string, timeoutMs int) (any, error) {
+	if timeoutMs <= 0 {
+		timeoutMs = 5000
+	}
+	timeoutMs = min(timeoutMs, maxAsyncScriptTimeout)
+
+	wrapped := fmt.Sprintf(`
+		new Promise((resolve) => {
+			const timer = setTimeout(() => resolve({ timeout: true }), %d);
+			const done = (value) => {
+				clearTimeout(timer);
+				resolve({ value });
+			};
+			try {
+				(function () {
+`, timeoutMs) + script + `
+				}).call(window, done);
+			} catch (e) {
+				clearTimeout(timer);
+				resolve({ error: e.message });
+			}
+		})
+	`
+	result, err := c.executeScriptInWorld(ctx, tabID, wrapped, "MAIN")
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	m, _ := result.(map[string]any)
+	if timedOut, _ := m["timeout"].(bool); timedOut {
+		return nil, fmt.Errorf("async script did not call done within %dms: %w", timeoutMs, ErrTimeout)
+	}
+	return m["value"], nil
+}
+
+// maxWorkerScriptSize limits the script source ExecuteInWorker accepts.
+const maxWorkerScriptSize = 1 << 20
+
+// ExecuteInWorker runs script in a dedicated Web Worker created from a blob
+// in the tab, as the body of an async function, and returns its result.
+// The worker is terminated afterwards. Returns ErrPayloadTooLarge for
+// scripts over 1 MB and ErrTimeout if the worker does not finish within
+// timeoutMs (default 5000, at most 25000).
+func (c *Controller) ExecuteInWorker(ctx context.Context, tabID int, script string, timeoutMs int) (any, error) {
+	if len(script) > maxWorkerScriptSize {
+		return nil, fmt.Errorf("worker script is %d bytes, limit is %d: %w", len(script), maxWorkerScriptSize, ErrPayloadTooLarge)
+	}
+	if timeoutMs <= 0 {
+		timeoutMs = 5000
+	}
+	timeoutMs = min(timeoutMs, maxAsyncScriptTimeout)
+
+	source, _ := json.Marshal(`
+		(async function () {
+` + script + `
+		})().then(
+			value => postMessage({ value }),
+			e => postMessage({ error: String(e && e.message || e) })
+		);
+	`)
+	wrapped := fmt.Sprintf(`
+		new Promise((resolve) => {
+			let worker, url;
+			try {
+				url = URL.createObjectURL(new Blob([%s], { type: 'text/javascript' }));
+				worker = new Worker(url);
+			} catch (e) {
+				if (url) URL.revokeObjectURL(url);
+				resolve({ error: 'Failed to start worker: ' + e.message });
+				return;
+			}
+			const finish = (result) => {
+				clearTimeout(timer);
+				worker.terminate();
+				URL.revokeObjectURL(url);
+				resolve(result);
+			};
+			const timer = setTimeout(() => finish({ timeout: true }), %d);
+			worker.onmessage = (e) => finish(e.data);
+			worker.onerror = (e) => finish({ error: e.message || 'Worker error' });
+		})
+	`, source, timeoutMs)
+
+	result, err := c.ExecuteScript(ctx, tabID, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	m, _ := result.(map[string]any)
+	if timedOut, _ := m["timeout"].(bool); timedOut {
+		return nil, fmt.Errorf("worker did not finish within %dms: %w", timeoutMs, ErrTimeout)
+	}
+	return m["value"], nil
+}
+
+// executeScriptInWorld runs JavaScript in the given execution world: "MAIN"
+// shares globals with the page's own scripts, while "" uses the extension's
+// isolated world. Main-world evaluation may be blocked by a strict page CSP.
+func (c *Controller) executeScriptInWorld(ctx context.Context, tabID int, script string, world string) (any, error) {
+	params := map[string]any{
 		"tabId":  tabID,
 		"script": script,
-	})
+	}
+	if world != "" {
+		params["world"] = world
+	}
+	return c.runScript(ctx, params)
+}
+
+// runScript sends a browser.scripting.executeScript request and returns the
+// result of the first injection.
+func (c *Controller) runScript(ctx context.Context, params map[string]any) (any, error) {
+	logging.LoggerFromContext(ctx).Debug("executing script",
+		"tabId", params["tabId"], "frameId", params["frameId"], "world", params["world"])
+	resp, err := c.send(ctx, "browser.scripting.executeScript", params)
 	if err != nil {
 		return nil, err
 	}
@@ -169,12 +709,12 @@ func (c *Controller) ExecuteScript(ctx context.Context, tabID int, script string
 func (c *Controller) ClickElement(ctx context.Context, tabID int, selector string) error {
 	script := fmt.Sprintf(`
 		(() => {
-			const el = document.querySelector(%q);
-			if (!el) return { error: 'Element not found' };
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
 			el.click();
 			return { clicked: true, tagName: el.tagName };
 		})()
-	`, selector)
+	`, querySelectorJS(selector))
 
 	result, err := c.ExecuteScript(ctx, tabID, script)
 	if err != nil {
@@ -182,56 +722,246 @@ func (c *Controller) ClickElement(ctx context.Context, tabID int, selector strin
 	}
 
 	// Check for error in result
-	if m, ok := result.(map[string]any); ok {
-		if errMsg, ok := m["error"].(string); ok {
-			return fmt.Errorf("%s", errMsg)
-		}
+	return resultError(result)
+}
+
+// RightClick dispatches a contextmenu event on the element matched by
+// selector, as a right mouse click at its center would.
+func (c *Controller) RightClick(ctx context.Context, tabID int, selector string) error {
+	return c.dispatchMouseEvents(ctx, tabID, selector, `[['contextmenu', 2, 2]]`)
+}
+
+// DoubleClick dispatches two click events followed by dblclick on the
+// element matched by selector.
+func (c *Controller) DoubleClick(ctx context.Context, tabID int, selector string) error {
+	return c.dispatchMouseEvents(ctx, tabID, selector, `[['click', 0, 0, 1], ['click', 0, 0, 2], ['dblclick', 0, 0, 2]]`)
+}
+
+// dispatchMouseEvents dispatches MouseEvents at the center of the element
+// matched by selector. events is a JavaScript array of
+// [type, button, buttons, detail] tuples.
+func (c *Controller) dispatchMouseEvents(ctx context.Context, tabID int, selector, events string) error {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			const rect = el.getBoundingClientRect();
+			const clientX = rect.left + rect.width / 2, clientY = rect.top + rect.height / 2;
+			for (const [type, button, buttons, detail] of %s) {
+				el.dispatchEvent(new MouseEvent(type, {
+					bubbles: true, cancelable: true, view: window,
+					clientX, clientY, button, buttons, detail: detail || 1
+				}));
+			}
+			return { clicked: true, tagName: el.tagName };
+		})()
+	`, querySelectorJS(selector), events)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
 	}
-	return nil
+	return resultError(result)
+}
+
+// ClickAt clicks the element at viewport coordinates x, y with the given
+// button: "left" (default), "right" or "middle". Non-left buttons fire
+// auxclick instead of click, and right clicks also fire contextmenu, as a
+// real click would.
+func (c *Controller) ClickAt(ctx context.Context, tabID int, x, y int, button string) error {
+	buttons := map[string]int{"": 0, "left": 0, "middle": 1, "right": 2}
+	code, ok := buttons[button]
+	if !ok {
+		return fmt.Errorf("invalid button %q: must be left, right or middle", button)
+	}
+
+	script := fmt.Sprintf(`
+		(() => {
+			const x = %d, y = %d, button = %d;
+			const el = document.elementFromPoint(x, y);
+			if (!el) return { error: 'No element at ' + x + ',' + y, code: 'not_found' };
+			const init = { bubbles: true, cancelable: true, view: window, clientX: x, clientY: y, button };
+			el.dispatchEvent(new MouseEvent('mousedown', { ...init, buttons: 1 << [0, 2, 1][button] }));
+			el.dispatchEvent(new MouseEvent('mouseup', init));
+			el.dispatchEvent(new MouseEvent(button === 0 ? 'click' : 'auxclick', init));
+			if (button === 2) el.dispatchEvent(new MouseEvent('contextmenu', init));
+			return { clicked: true, tagName: el.tagName };
+		})()
+	`, x, y, code)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
 }
 
 // FillInput fills an input field.
 func (c *Controller) FillInput(ctx context.Context, tabID int, selector, value string) error {
 	script := fmt.Sprintf(`
 		(() => {
-			const el = document.querySelector(%q);
-			if (!el) return { error: 'Element not found' };
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
 			el.value = %q;
 			el.dispatchEvent(new Event('input', { bubbles: true }));
 			el.dispatchEvent(new Event('change', { bubbles: true }));
 			return { filled: true, tagName: el.tagName };
 		})()
-	`, selector, value)
+	`, querySelectorJS(selector), value)
 
 	result, err := c.ExecuteScript(ctx, tabID, script)
 	if err != nil {
 		return err
 	}
 
-	if m, ok := result.(map[string]any); ok {
-		if errMsg, ok := m["error"].(string); ok {
-			return fmt.Errorf("%s", errMsg)
-		}
-	}
-	return nil
+	return resultError(result)
 }
 
-// ScrollPage scrolls the page.
-func (c *Controller) ScrollPage(ctx context.Context, tabID int, x, y int) error {
+// ClearInput empties an input, textarea or contenteditable element and
+// fires the events frameworks listen for, unlike FillInput with "".
+func (c *Controller) ClearInput(ctx context.Context, tabID int, selector string) error {
 	script := fmt.Sprintf(`
-		window.scrollTo(%d, %d);
-		return { scrollX: window.scrollX, scrollY: window.scrollY };
-	`, x, y)
-
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			if (el.isContentEditable) {
+				el.focus();
+				document.execCommand('selectAll');
+				document.execCommand('delete');
+				return { cleared: true, tagName: el.tagName };
+			}
+			if (!('value' in el)) return { error: 'Element is not an input, textarea or contenteditable', code: 'wrong_type' };
+			el.value = '';
+			el.dispatchEvent(new Event('input', { bubbles: true }));
+			el.dispatchEvent(new Event('change', { bubbles: true }));
+			return { cleared: true, tagName: el.tagName };
+		})()
+	`, querySelectorJS(selector))
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+
+	return resultError(result)
+}
+
+// ScrollPage scrolls the page.
+func (c *Controller) ScrollPage(ctx context.Context, tabID int, x, y int) error {
+	script := fmt.Sprintf(`
+		window.scrollTo(%d, %d);
+		return { scrollX: window.scrollX, scrollY: window.scrollY };
+	`, x, y)
+
+	_, err := c.ExecuteScript(ctx, tabID, script)
+	return err
+}
+
+// ScrollBy scrolls the page by deltaX, deltaY pixels. Behavior is "auto"
+// (default) or "smooth".
+func (c *Controller) ScrollBy(ctx context.Context, tabID int, deltaX, deltaY int, behavior string) error {
+	if behavior == "" {
+		behavior = "auto"
+	}
+	script := fmt.Sprintf(`
+		(() => {
+			window.scrollBy({ left: %d, top: %d, behavior: %q });
+			return { scrollX: window.scrollX, scrollY: window.scrollY };
+		})()
+	`, deltaX, deltaY, behavior)
+
 	_, err := c.ExecuteScript(ctx, tabID, script)
 	return err
 }
 
+// ScrollElementBy scrolls the scrollable container matched by selector by
+// deltaX, deltaY pixels.
+func (c *Controller) ScrollElementBy(ctx context.Context, tabID int, selector string, deltaX, deltaY int) error {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			el.scrollBy(%d, %d);
+			return { scrollLeft: el.scrollLeft, scrollTop: el.scrollTop };
+		})()
+	`, querySelectorJS(selector), deltaX, deltaY)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// GetScrollPosition returns how far the page is scrolled horizontally and
+// vertically.
+func (c *Controller) GetScrollPosition(ctx context.Context, tabID int) (x, y int, err error) {
+	state, err := c.scrollState(ctx, tabID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return state.X, state.Y, nil
+}
+
+// GetScrollSize returns the full scrollable width and height of the page.
+func (c *Controller) GetScrollSize(ctx context.Context, tabID int) (width, height int, err error) {
+	state, err := c.scrollState(ctx, tabID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return state.Width, state.Height, nil
+}
+
+// scrollState reads the page's scroll offset and scrollable size.
+func (c *Controller) scrollState(ctx context.Context, tabID int) (*mcp.ScrollState, error) {
+	script := `
+		({
+			x: Math.round(window.scrollX),
+			y: Math.round(window.scrollY),
+			width: document.documentElement.scrollWidth,
+			height: document.documentElement.scrollHeight
+		})
+	`
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &mcp.ScrollState{}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scroll state: %w", err)
+	}
+	return state, nil
+}
+
+// ScrollToElement scrolls the element matched by selector into the center of
+// the viewport. Behavior is "auto" (default) or "smooth".
+func (c *Controller) ScrollToElement(ctx context.Context, tabID int, selector string, behavior string) error {
+	if behavior == "" {
+		behavior = "auto"
+	}
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			el.scrollIntoView({ behavior: %q, block: 'center' });
+			return { scrollX: window.scrollX, scrollY: window.scrollY };
+		})()
+	`, querySelectorJS(selector), behavior)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
 // FindElements finds elements by CSS selector.
 func (c *Controller) FindElements(ctx context.Context, tabID int, selector string) (*mcp.FindResult, error) {
 	script := fmt.Sprintf(`
 		(() => {
-			const elements = Array.from(document.querySelectorAll(%q));
+			const elements = %s;
 			return {
 				count: elements.length,
 				elements: elements.map(el => ({
@@ -241,7 +971,7 @@ func (c *Controller) FindElements(ctx context.Context, tabID int, selector strin
 				}))
 			};
 		})()
-	`, selector)
+	`, querySelectorAllJS(selector))
 
 	result, err := c.ExecuteScript(ctx, tabID, script)
 	if err != nil {
@@ -256,6 +986,1390 @@ func (c *Controller) FindElements(ctx context.Context, tabID int, selector strin
 	return &findResult, nil
 }
 
+// GetAccessibilityTree returns the accessibility tree of a tab.
+// The tree is requested from the browser's automation API via the extension;
+// if that API is unavailable, a simplified tree is built from ARIA attributes
+// and implicit element roles.
+func (c *Controller) GetAccessibilityTree(ctx context.Context, tabID int) (*mcp.AccessibilityNode, error) {
+	resp, err := c.send(ctx, "browser.automation.getAccessibilityTree", map[string]any{
+		"tabId": tabID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error == nil {
+		var root mcp.AccessibilityNode
+		if err := json.Unmarshal(resp.Result, &root); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal accessibility tree: %w", err)
+		}
+		return &root, nil
+	}
+
+	// Fall back to an ARIA-based tree built in the page
+	result, err := c.ExecuteScript(ctx, tabID, accessibilityTreeScript)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(result)
+	var root mcp.AccessibilityNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal accessibility tree: %w", err)
+	}
+	return &root, nil
+}
+
+// accessibilityTreeScript builds a simplified accessibility tree from ARIA
+// attributes and implicit roles. Elements without a role are flattened into
+// their parent.
+const accessibilityTreeScript = `
+	(() => {
+		const implicitRoles = {
+			A: 'link', BUTTON: 'button', NAV: 'navigation', MAIN: 'main',
+			HEADER: 'banner', FOOTER: 'contentinfo', ASIDE: 'complementary',
+			FORM: 'form', IMG: 'img', UL: 'list', OL: 'list', LI: 'listitem',
+			TABLE: 'table', TR: 'row', TH: 'columnheader', TD: 'cell',
+			SELECT: 'combobox', TEXTAREA: 'textbox', DIALOG: 'dialog',
+			H1: 'heading', H2: 'heading', H3: 'heading',
+			H4: 'heading', H5: 'heading', H6: 'heading'
+		};
+		const inputRoles = {
+			checkbox: 'checkbox', radio: 'radio', button: 'button',
+			submit: 'button', reset: 'button', range: 'slider', search: 'searchbox'
+		};
+		const roleOf = (el) => {
+			if (el.getAttribute('role')) return el.getAttribute('role');
+			if (el.tagName === 'INPUT') return inputRoles[el.type] || 'textbox';
+			if (el.tagName === 'A' && !el.hasAttribute('href')) return null;
+			return implicitRoles[el.tagName] || null;
+		};
+		const textOf = (ids) => ids.split(/\s+/)
+			.map(id => document.getElementById(id)?.textContent.trim() || '')
+			.join(' ').trim();
+		const nameOf = (el, role) => {
+			if (el.getAttribute('aria-label')) return el.getAttribute('aria-label');
+			if (el.getAttribute('aria-labelledby')) return textOf(el.getAttribute('aria-labelledby'));
+			if (el.alt) return el.alt;
+			if (el.labels && el.labels.length) return el.labels[0].textContent.trim();
+			if (el.title) return el.title;
+			if (['link', 'button', 'heading', 'listitem', 'cell', 'columnheader', 'checkbox', 'radio'].includes(role)) {
+				return (el.innerText || el.textContent || '').trim().slice(0, 200);
+			}
+			return el.placeholder || '';
+		};
+		const walk = (el) => {
+			const children = [];
+			for (const child of el.children) {
+				if (child.getAttribute('aria-hidden') === 'true' || child.hidden) continue;
+				children.push(...walk(child));
+			}
+			const role = roleOf(el);
+			if (!role) return children;
+			const rect = el.getBoundingClientRect();
+			const node = {
+				role,
+				name: nameOf(el, role),
+				bounds: { x: rect.x, y: rect.y, width: rect.width, height: rect.height }
+			};
+			const describedBy = el.getAttribute('aria-describedby');
+			if (describedBy) node.description = textOf(describedBy);
+			if (children.length) node.children = children;
+			return [node];
+		};
+		return {
+			role: 'document',
+			name: document.title,
+			children: document.body ? walk(document.body) : []
+		};
+	})()
+`
+
+// EvaluateXPath evaluates an XPath expression in a tab. The expected result
+// type is inferred from the expression's outermost function.
+func (c *Controller) EvaluateXPath(ctx context.Context, tabID int, expression string, contextSelector string) (*mcp.XPathResult, error) {
+	resultType, constant := xpathResultType(expression)
+	script := fmt.Sprintf(`
+		(() => {
+			const contextNode = %q ? document.querySelector(%q) : document;
+			if (!contextNode) return { error: 'Context element not found' };
+			const res = document.evaluate(%q, contextNode, null, XPathResult.%s, null);
+			switch (res.resultType) {
+				case XPathResult.NUMBER_TYPE: return { value: res.numberValue };
+				case XPathResult.STRING_TYPE: return { value: res.stringValue };
+				case XPathResult.BOOLEAN_TYPE: return { value: res.booleanValue };
+			}
+			const nodes = [];
+			for (let i = 0; i < res.snapshotLength; i++) {
+				const node = res.snapshotItem(i);
+				nodes.push(node.nodeType === Node.ELEMENT_NODE
+					? { tagName: node.tagName, text: node.innerText?.slice(0, 200) }
+					: { nodeName: node.nodeName, text: node.nodeValue?.slice(0, 200) });
+			}
+			return { value: nodes };
+		})()
+	`, contextSelector, contextSelector, expression, constant)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := result.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected XPath result: %v", result)
+	}
+	if errMsg, ok := m["error"].(string); ok {
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+	return &mcp.XPathResult{ResultType: resultType, Value: m["value"]}, nil
+}
+
+// xpathResultType infers the result type of an XPath expression and returns
+// it together with the matching XPathResult constant name.
+func xpathResultType(expression string) (string, string) {
+	expr := strings.TrimSpace(expression)
+	name := expr
+	if i := strings.Index(expr, "("); i > 0 {
+		name = strings.TrimSpace(expr[:i])
+	}
+
+	switch name {
+	case "count", "sum", "number", "string-length", "floor", "ceiling", "round":
+		return "number", "NUMBER_TYPE"
+	case "string", "concat", "normalize-space", "substring", "substring-before",
+		"substring-after", "translate", "name", "local-name", "namespace-uri":
+		return "string", "STRING_TYPE"
+	case "boolean", "not", "contains", "starts-with", "true", "false", "lang":
+		return "boolean", "BOOLEAN_TYPE"
+	}
+	return "nodes", "ORDERED_NODE_SNAPSHOT_TYPE"
+}
+
+// ObserveMutations records DOM mutations under the element matched by
+// selector for the given duration, at most 25 seconds, and returns them.
+// This is a single-shot observation, not a persistent subscription.
+func (c *Controller) ObserveMutations(ctx context.Context, tabID int, selector string, timeout time.Duration) ([]mcp.DomMutation, error) {
+	if selector == "" {
+		selector = "body"
+	}
+	timeout = min(timeout, maxWaitTimeout)
+	script := fmt.Sprintf(`
+		new Promise((resolve) => {
+			const root = %s;
+			if (!root) return resolve({ error: 'Element not found', code: 'not_found' });
+			%s
+			const mutations = [];
+			const observer = new MutationObserver((records) => {
+				for (const r of records) {
+					const target = r.target.nodeType === Node.ELEMENT_NODE ? r.target : r.target.parentElement;
+					mutations.push({
+						type: r.type,
+						targetSelector: cssPath(target),
+						attributeName: r.attributeName || undefined,
+						addedNodes: r.addedNodes.length,
+						removedNodes: r.removedNodes.length
+					});
+				}
+			});
+			observer.observe(root, { childList: true, attributes: true, characterData: true, subtree: true });
+			setTimeout(() => {
+				observer.disconnect();
+				resolve({ mutations });
+			}, %d);
+		})
+	`, querySelectorJS(selector), cssPathJS, timeout.Milliseconds())
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	var observed struct {
+		Mutations []mcp.DomMutation `json:"mutations"`
+	}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, &observed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mutations: %w", err)
+	}
+	return observed.Mutations, nil
+}
+
+// GetBoundingRect returns the bounding client rect of an element. When
+// includeScrollOffset is true, the current scroll position is added so the
+// coordinates are relative to the document rather than the viewport.
+func (c *Controller) GetBoundingRect(ctx context.Context, tabID int, selector string, includeScrollOffset bool) (*mcp.BoundingRect, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			const r = el.getBoundingClientRect();
+			const dx = %t ? window.scrollX : 0;
+			const dy = %t ? window.scrollY : 0;
+			return {
+				top: r.top + dy, left: r.left + dx,
+				bottom: r.bottom + dy, right: r.right + dx,
+				width: r.width, height: r.height,
+				x: r.x + dx, y: r.y + dy
+			};
+		})()
+	`, querySelectorJS(selector), includeScrollOffset, includeScrollOffset)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(result)
+	var rect mcp.BoundingRect
+	if err := json.Unmarshal(data, &rect); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bounding rect: %w", err)
+	}
+	return &rect, nil
+}
+
+// BatchTabOp runs tab operations concurrently, limited by the controller's
+// batch concurrency, and returns results in the same order as ops.
+// Failures are reported per operation rather than failing the whole batch.
+// Once ctx is done no further operations are started, and those not yet
+// started report the context's error.
+func (c *Controller) BatchTabOp(ctx context.Context, ops []mcp.TabOp) ([]mcp.TabOpResult, error) {
+	results := make([]mcp.TabOpResult, len(ops))
+	sem := make(chan struct{}, c.batchConcurrency)
+	var wg sync.WaitGroup
+
+	for i, op := range ops {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		// Checked again as select picks at random when both are ready
+		if ctx.Err() != nil {
+			for j := i; j < len(ops); j++ {
+				results[j] = mcp.TabOpResult{TabID: ops[j].TabID, Type: ops[j].Type, Error: ctx.Err().Error()}
+			}
+			break
+		}
+		wg.Add(1)
+		go func(i int, op mcp.TabOp) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res := mcp.TabOpResult{TabID: op.TabID, Type: op.Type}
+			result, err := c.runTabOp(ctx, op)
+			if err != nil {
+				res.Error = err.Error()
+			} else {
+				res.Result = result
+			}
+			results[i] = res
+		}(i, op)
+	}
+
+	wg.Wait()
+	return results, nil
+}
+
+func (c *Controller) runTabOp(ctx context.Context, op mcp.TabOp) (any, error) {
+	switch op.Type {
+	case "content":
+		return c.GetPageContent(ctx, op.TabID)
+	case "screenshot":
+		return c.ScreenshotTab(ctx, op.TabID)
+	case "navigate":
+		return nil, c.NavigateTab(ctx, op.TabID, op.URL)
+	case "close":
+		return nil, c.CloseTab(ctx, op.TabID)
+	case "execute":
+		return c.ExecuteScript(ctx, op.TabID, op.Script)
+	case "find":
+		return c.FindElements(ctx, op.TabID, op.Selector)
+	default:
+		return nil, fmt.Errorf("unknown tab operation: %s", op.Type)
+	}
+}
+
+// SetCheckbox sets the checked state of a checkbox input. The element is
+// clicked only when its current state differs, so change handlers fire as
+// they would for a user. Returns ErrWrongType if the element is not a
+// checkbox input.
+func (c *Controller) SetCheckbox(ctx context.Context, tabID int, selector string, checked bool) error {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			if (el.tagName !== 'INPUT' || el.type !== 'checkbox') {
+				const kind = el.tagName === 'INPUT' ? 'INPUT type=' + el.type : el.tagName;
+				return { error: 'Element is not a checkbox: ' + kind, code: 'wrong_type' };
+			}
+			if (el.checked !== %t) el.click();
+			return { checked: el.checked };
+		})()
+	`, querySelectorJS(selector), checked)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// FocusElement focuses an element and dispatches a focus event for
+// frameworks that listen for it explicitly.
+func (c *Controller) FocusElement(ctx context.Context, tabID int, selector string) error {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			el.focus();
+			el.dispatchEvent(new FocusEvent('focus'));
+			return { focused: document.activeElement === el, tagName: el.tagName };
+		})()
+	`, querySelectorJS(selector))
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// BlurElement removes focus from an element.
+func (c *Controller) BlurElement(ctx context.Context, tabID int, selector string) error {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			el.blur();
+			return { blurred: true, tagName: el.tagName };
+		})()
+	`, querySelectorJS(selector))
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// HandleDialog accepts or dismisses the JavaScript dialog open in a tab.
+// Action is "accept" or "dismiss"; promptText is entered into prompt dialogs.
+func (c *Controller) HandleDialog(ctx context.Context, tabID int, action string, promptText string) error {
+	if action != "accept" && action != "dismiss" {
+		return fmt.Errorf("invalid dialog action %q: must be accept or dismiss", action)
+	}
+
+	resp, err := c.send(ctx, "browser.dialog.respond", map[string]any{
+		"tabId":      tabID,
+		"accept":     action == "accept",
+		"promptText": promptText,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return nil
+}
+
+// GetPendingDialog returns the JavaScript dialog open in a tab, or nil if
+// there is none.
+func (c *Controller) GetPendingDialog(ctx context.Context, tabID int) (*mcp.PendingDialog, error) {
+	resp, err := c.send(ctx, "browser.dialog.getPending", map[string]any{
+		"tabId": tabID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	var dialog *mcp.PendingDialog
+	if err := json.Unmarshal(resp.Result, &dialog); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dialog: %w", err)
+	}
+	return dialog, nil
+}
+
+// GetMetaTags returns the attributes of every <meta> element on the page.
+func (c *Controller) GetMetaTags(ctx context.Context, tabID int) ([]mcp.MetaTag, error) {
+	script := `
+		Array.from(document.querySelectorAll('meta')).map(m => ({
+			name: m.getAttribute('name') || undefined,
+			property: m.getAttribute('property') || undefined,
+			httpEquiv: m.getAttribute('http-equiv') || undefined,
+			content: m.getAttribute('content') || undefined,
+			charset: m.getAttribute('charset') || undefined
+		}))
+	`
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(result)
+	var tags []mcp.MetaTag
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal meta tags: %w", err)
+	}
+	return tags, nil
+}
+
+// GetOpenGraphTags returns the page's og:* meta properties keyed by
+// property name. If a property is repeated, the first value wins.
+func (c *Controller) GetOpenGraphTags(ctx context.Context, tabID int) (map[string]string, error) {
+	tags, err := c.GetMetaTags(ctx, tabID)
+	if err != nil {
+		return nil, err
+	}
+
+	og := make(map[string]string)
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag.Property, "og:") {
+			continue
+		}
+		if _, ok := og[tag.Property]; !ok {
+			og[tag.Property] = tag.Content
+		}
+	}
+	return og, nil
+}
+
+// HighlightElements outlines the elements matched by selector for durationMs
+// milliseconds and returns how many were matched. The outline is applied
+// through a uniquely named class so it does not clash with page styles.
+func (c *Controller) HighlightElements(ctx context.Context, tabID int, selector string, color string, durationMs int) (int, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			const elements = %s;
+			const color = CSS.supports('color', %q) ? %q : 'red';
+			const cls = '__mcp-highlight-' + Math.random().toString(36).slice(2);
+			const style = document.createElement('style');
+			style.textContent = '.' + cls + ' { outline: 3px solid ' + color + ' !important; outline-offset: 2px !important; }';
+			document.documentElement.appendChild(style);
+			elements.forEach(el => el.classList.add(cls));
+			setTimeout(() => {
+				elements.forEach(el => el.classList.remove(cls));
+				style.remove();
+			}, %d);
+			return elements.length;
+		})()
+	`, querySelectorAllJS(selector), color, color, durationMs)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return 0, err
+	}
+	count, _ := result.(float64)
+	return int(count), nil
+}
+
+// labelControlJS defines a JavaScript function labelControl(wanted) that
+// returns { label, el } for the form control labelled by wanted, or an
+// { error, code } object. The control is the label's for= target or nested
+// control, falling back to the nearest control following the label.
+const labelControlJS = `
+	const labelText = (l) => l.textContent.replace(/\s+/g, ' ').trim();
+	const labelControl = (wanted) => {
+		wanted = wanted.trim();
+		const labels = Array.from(document.querySelectorAll('label'));
+		const label = labels.find(l => labelText(l) === wanted) ||
+			labels.find(l => labelText(l).toLowerCase().includes(wanted.toLowerCase()));
+		if (!label) return { error: 'No label matching: ' + wanted, code: 'not_found' };
+
+		const controls = 'input:not([type=hidden]), select, textarea, [contenteditable=""], [contenteditable=true]';
+		let el = label.control;
+		if (!el) {
+			const all = Array.from(document.querySelectorAll(controls));
+			el = all.find(c => label.compareDocumentPosition(c) & Node.DOCUMENT_POSITION_FOLLOWING);
+		}
+		if (!el) return { error: 'No control associated with label: ' + wanted, code: 'not_found' };
+		return { label, el };
+	};
+`
+
+// GetElementByLabel finds the form control labelled by labelText, as
+// resolved by labelControlJS. The returned Selector addresses the control
+// directly.
+func (c *Controller) GetElementByLabel(ctx context.Context, tabID int, labelText string) (*mcp.ElementInfo, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			%s
+			%s
+			const found = labelControl(%q);
+			if (found.error) return found;
+			const { label, el } = found;
+			return {
+				tagName: el.tagName,
+				text: labelText(label),
+				visible: el.offsetParent !== null,
+				selector: cssPath(el)
+			};
+		})()
+	`, cssPathJS, labelControlJS, labelText)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(result)
+	var info mcp.ElementInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal element: %w", err)
+	}
+	return &info, nil
+}
+
+// FillByLabel fills the form control labelled by labelText. The control is
+// resolved and filled by the same script, so a selector for it never has to
+// be built.
+func (c *Controller) FillByLabel(ctx context.Context, tabID int, labelText, value string) error {
+	script := fmt.Sprintf(`
+		(() => {
+			%s
+			const found = labelControl(%q);
+			if (found.error) return found;
+			const el = found.el;
+			if (el.isContentEditable) el.textContent = %q;
+			else el.value = %q;
+			el.dispatchEvent(new Event('input', { bubbles: true }));
+			el.dispatchEvent(new Event('change', { bubbles: true }));
+			return { filled: true, tagName: el.tagName };
+		})()
+	`, labelControlJS, labelText, value, value)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// ResolveTabID returns the ID of the first tab whose URL matches the glob
+// pattern tabURL, in which * also matches /. See compileURLGlob.
+func (c *Controller) ResolveTabID(ctx context.Context, tabURL string) (int, error) {
+	glob, err := compileURLGlob(tabURL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tabUrl pattern %q: %w", tabURL, err)
+	}
+	tabs, err := c.ListTabs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, tab := range tabs {
+		if glob.MatchString(tab.URL) {
+			return tab.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("no tab matches %q", tabURL)
+}
+
+// GetStructuredData extracts the page's JSON-LD blocks and top-level
+// microdata items. JSON-LD blocks that are not valid JSON are skipped.
+func (c *Controller) GetStructuredData(ctx context.Context, tabID int) (*mcp.StructuredData, error) {
+	script := `
+		(() => {
+			const propValue = (el) => {
+				switch (el.tagName) {
+					case 'META': return el.getAttribute('content') || '';
+					case 'AUDIO': case 'EMBED': case 'IFRAME': case 'IMG':
+					case 'SOURCE': case 'TRACK': case 'VIDEO': return el.src;
+					case 'A': case 'AREA': case 'LINK': return el.href;
+					case 'OBJECT': return el.data;
+					case 'DATA': case 'METER': return el.getAttribute('value') || '';
+					case 'TIME': return el.getAttribute('datetime') || el.textContent.trim();
+					default: return el.textContent.trim();
+				}
+			};
+			const readItem = (scope) => {
+				const item = {
+					type: scope.getAttribute('itemtype') || undefined,
+					id: scope.getAttribute('itemid') || undefined,
+					properties: {}
+				};
+				const walk = (el) => {
+					for (const child of el.children) {
+						const prop = child.getAttribute('itemprop');
+						if (prop) {
+							const value = child.hasAttribute('itemscope') ? readItem(child) : propValue(child);
+							for (const name of prop.trim().split(/\s+/)) {
+								(item.properties[name] = item.properties[name] || []).push(value);
+							}
+						}
+						if (!child.hasAttribute('itemscope')) walk(child);
+					}
+				};
+				walk(scope);
+				return item;
+			};
+			return {
+				jsonLd: Array.from(document.querySelectorAll('script[type="application/ld+json"]')).map(s => s.textContent),
+				microdata: Array.from(document.querySelectorAll('[itemscope]:not([itemprop])')).map(readItem)
+			};
+		})()
+	`
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		JSONLD    []string            `json:"jsonLd"`
+		Microdata []mcp.MicrodataItem `json:"microdata"`
+	}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal structured data: %w", err)
+	}
+
+	structured := &mcp.StructuredData{
+		JSONLD:    []json.RawMessage{},
+		Microdata: raw.Microdata,
+	}
+	for _, block := range raw.JSONLD {
+		block = strings.TrimSpace(block)
+		if json.Valid([]byte(block)) {
+			structured.JSONLD = append(structured.JSONLD, json.RawMessage(block))
+		}
+	}
+	if structured.Microdata == nil {
+		structured.Microdata = []mcp.MicrodataItem{}
+	}
+	return structured, nil
+}
+
+// GetSelection returns the text currently selected in a tab.
+func (c *Controller) GetSelection(ctx context.Context, tabID int) (string, error) {
+	result, err := c.ExecuteScript(ctx, tabID, "window.getSelection().toString()")
+	if err != nil {
+		return "", err
+	}
+	text, _ := result.(string)
+	return text, nil
+}
+
+// SetSelection selects the characters from start to end (exclusive) of the
+// text inside the element matched by selector. Inputs and textareas use
+// their own selection range; other elements are walked text node by node.
+func (c *Controller) SetSelection(ctx context.Context, tabID int, selector string, start, end int) error {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			const start = %d, end = %d;
+			if (start < 0 || end < start) return { error: 'Invalid range: start must be >= 0 and end >= start' };
+			if (typeof el.setSelectionRange === 'function') {
+				if (end > el.value.length) return { error: 'Range exceeds text length ' + el.value.length };
+				el.focus();
+				el.setSelectionRange(start, end);
+				return { selected: el.value.slice(start, end) };
+			}
+			const walker = document.createTreeWalker(el, NodeFilter.SHOW_TEXT);
+			const range = document.createRange();
+			let offset = 0, startSet = false, node;
+			while ((node = walker.nextNode())) {
+				const length = node.textContent.length;
+				if (!startSet && start <= offset + length) {
+					range.setStart(node, start - offset);
+					startSet = true;
+				}
+				if (startSet && end <= offset + length) {
+					range.setEnd(node, end - offset);
+					const selection = window.getSelection();
+					selection.removeAllRanges();
+					selection.addRange(range);
+					return { selected: selection.toString() };
+				}
+				offset += length;
+			}
+			return { error: 'Range exceeds text length ' + offset };
+		})()
+	`, querySelectorJS(selector), start, end)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// GetElementText returns the innerText of the element matched by selector.
+// Returns ErrNotFound if nothing matches.
+func (c *Controller) GetElementText(ctx context.Context, tabID int, selector string) (string, error) {
+	return c.elementProperty(ctx, tabID, selector, "innerText")
+}
+
+// GetElementHTML returns the innerHTML of the element matched by selector.
+// Returns ErrNotFound if nothing matches.
+func (c *Controller) GetElementHTML(ctx context.Context, tabID int, selector string) (string, error) {
+	return c.elementProperty(ctx, tabID, selector, "innerHTML")
+}
+
+// elementProperty reads a string property of the element matched by selector.
+func (c *Controller) elementProperty(ctx context.Context, tabID int, selector, property string) (string, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			return { value: el[%q] ?? '' };
+		})()
+	`, querySelectorJS(selector), property)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return "", err
+	}
+	if err := resultError(result); err != nil {
+		return "", err
+	}
+	m, _ := result.(map[string]any)
+	value, _ := m["value"].(string)
+	return value, nil
+}
+
+// GetTextNodes returns the trimmed value of every text node in the
+// document with at least minLength non-whitespace characters, in document
+// order. Script and style contents are skipped.
+func (c *Controller) GetTextNodes(ctx context.Context, tabID int, minLength int) ([]string, error) {
+	return c.textNodes(ctx, tabID, minLength, false)
+}
+
+// GetVisibleText returns the text nodes of the document whose parent
+// element is rendered and not hidden with visibility, joined by newlines.
+// Unlike an offsetParent check this keeps text in fixed-position elements.
+func (c *Controller) GetVisibleText(ctx context.Context, tabID int) (string, error) {
+	nodes, err := c.textNodes(ctx, tabID, 1, true)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(nodes, "\n"), nil
+}
+
+// textNodes collects text node values with a TreeWalker.
+func (c *Controller) textNodes(ctx context.Context, tabID int, minLength int, visibleOnly bool) ([]string, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			const minLength = %d, visibleOnly = %t;
+			const skip = new Set(['SCRIPT', 'STYLE', 'NOSCRIPT', 'TEMPLATE']);
+			const shown = new Map();
+			// Falls back to walking the computed styles of the ancestors
+			// where checkVisibility is not available
+			const isVisible = (el) => {
+				if (el.checkVisibility) return el.checkVisibility({ visibilityProperty: true });
+				if (getComputedStyle(el).visibility !== 'visible') return false;
+				for (let e = el; e; e = e.parentElement) {
+					if (!shown.has(e)) shown.set(e, getComputedStyle(e).display !== 'none');
+					if (!shown.get(e)) return false;
+				}
+				return true;
+			};
+			const walker = document.createTreeWalker(document.body || document.documentElement, NodeFilter.SHOW_TEXT);
+			const texts = [];
+			for (let node = walker.nextNode(); node; node = walker.nextNode()) {
+				const parent = node.parentElement;
+				if (!parent || skip.has(parent.tagName)) continue;
+				if (visibleOnly && !isVisible(parent)) continue;
+				if (node.nodeValue.replace(/\s/g, '').length < minLength) continue;
+				texts.push(node.nodeValue.trim());
+			}
+			return texts;
+		})()
+	`, minLength, visibleOnly)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := []string{}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, &texts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal text nodes: %w", err)
+	}
+	return texts, nil
+}
+
+// SetAttribute sets an attribute on the element matched by selector.
+// Returns ErrNotFound if nothing matches.
+func (c *Controller) SetAttribute(ctx context.Context, tabID int, selector, attribute, value string) error {
+	return c.modifyAttribute(ctx, tabID, selector, fmt.Sprintf("el.setAttribute(%q, %q)", attribute, value))
+}
+
+// RemoveAttribute removes an attribute from the element matched by
+// selector. Returns ErrNotFound if nothing matches.
+func (c *Controller) RemoveAttribute(ctx context.Context, tabID int, selector, attribute string) error {
+	return c.modifyAttribute(ctx, tabID, selector, fmt.Sprintf("el.removeAttribute(%q)", attribute))
+}
+
+// modifyAttribute runs an attribute mutation against el, the element
+// matched by selector.
+func (c *Controller) modifyAttribute(ctx context.Context, tabID int, selector, mutation string) error {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			try {
+				%s;
+			} catch (e) {
+				return { error: e.message };
+			}
+			return { modified: true };
+		})()
+	`, querySelectorJS(selector), mutation)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// CountElements returns how many elements match selector without
+// serializing them.
+func (c *Controller) CountElements(ctx context.Context, tabID int, selector string) (int, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			try {
+				return %s.length;
+			} catch (e) {
+				return { error: 'Invalid selector: ' + e.message };
+			}
+		})()
+	`, querySelectorAllJS(selector))
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return 0, err
+	}
+	if err := resultError(result); err != nil {
+		return 0, err
+	}
+	count, _ := result.(float64)
+	return int(count), nil
+}
+
+// ElementExists reports whether any element matches selector.
+func (c *Controller) ElementExists(ctx context.Context, tabID int, selector string) (bool, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			try {
+				return %s !== null;
+			} catch (e) {
+				return { error: 'Invalid selector: ' + e.message };
+			}
+		})()
+	`, querySelectorJS(selector))
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return false, err
+	}
+	if err := resultError(result); err != nil {
+		return false, err
+	}
+	exists, _ := result.(bool)
+	return exists, nil
+}
+
+// GetFormFields returns the controls of the form matched by formSelector
+// with their current values and a selector unique to each. Buttons and
+// fieldsets are skipped; selects report type "select" and list their
+// option values.
+func (c *Controller) GetFormFields(ctx context.Context, tabID int, formSelector string) ([]mcp.FormField, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			%s
+			const form = %s;
+			if (!form) return { error: 'Form not found', code: 'not_found' };
+			if (form.tagName !== 'FORM') return { error: 'Element is not a form: ' + form.tagName, code: 'wrong_type' };
+			const skip = ['submit', 'button', 'reset', 'image', 'fieldset', 'object', 'output'];
+			return Array.from(form.elements).filter(el => !skip.includes(el.type)).map(el => {
+				const field = {
+					name: el.name || '',
+					type: el.tagName === 'SELECT' ? 'select' : el.tagName === 'TEXTAREA' ? 'textarea' : el.type,
+					selector: uniqueSelector(el),
+					value: el.value,
+					required: el.required
+				};
+				if (el.type === 'checkbox' || el.type === 'radio') field.checked = el.checked;
+				if (el.tagName === 'SELECT') field.options = Array.from(el.options, o => o.value);
+				return field;
+			});
+		})()
+	`, uniqueSelectorJS, querySelectorJS(formSelector))
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	fields := []mcp.FormField{}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal form fields: %w", err)
+	}
+	return fields, nil
+}
+
+// maxInlineImageSize is the largest image GetImages embeds as base64.
+const maxInlineImageSize = 100 * 1024
+
+// imageFetchTimeout bounds how long GetImages spends downloading an image.
+const imageFetchTimeout = 10 * time.Second
+
+// GetImages lists the page's img elements. With includeBase64, images under
+// 100 KB are fetched and embedded as data URLs; downloads are abandoned as
+// soon as the Content-Length or the bytes received reach 100 KB, or after
+// 10 seconds. Images that cannot be fetched, for example cross-origin ones
+// without CORS, are returned without data. With loadedOnly, images that
+// have not finished loading or failed are skipped.
+func (c *Controller) GetImages(ctx context.Context, tabID int, loadedOnly, includeBase64 bool) ([]mcp.ImageInfo, error) {
+	script := fmt.Sprintf(`
+		(async () => {
+			const loadedOnly = %t, includeBase64 = %t, maxSize = %d, fetchTimeout = %d;
+			const images = Array.from(document.querySelectorAll('img'), img => ({
+				src: img.currentSrc || img.src,
+				alt: img.alt,
+				width: img.width,
+				height: img.height,
+				naturalWidth: img.naturalWidth,
+				naturalHeight: img.naturalHeight,
+				loaded: img.complete && img.naturalWidth > 0
+			})).filter(img => !loadedOnly || img.loaded);
+			if (includeBase64) {
+				await Promise.all(images.map(async (img) => {
+					const controller = new AbortController();
+					const timer = setTimeout(() => controller.abort(), fetchTimeout);
+					try {
+						const response = await fetch(img.src, { signal: controller.signal });
+						if (!response.ok || Number(response.headers.get('Content-Length')) >= maxSize) return;
+						const chunks = [];
+						let size = 0;
+						const body = response.body.getReader();
+						for (let chunk = await body.read(); !chunk.done; chunk = await body.read()) {
+							size += chunk.value.length;
+							if (size >= maxSize) return;
+							chunks.push(chunk.value);
+						}
+						const blob = new Blob(chunks, { type: response.headers.get('Content-Type') || '' });
+						img.data = await new Promise((resolve, reject) => {
+							const reader = new FileReader();
+							reader.onload = () => resolve(reader.result);
+							reader.onerror = () => reject(reader.error);
+							reader.readAsDataURL(blob);
+						});
+					} catch (e) {
+					} finally {
+						// Also stops downloads abandoned above
+						clearTimeout(timer);
+						controller.abort();
+					}
+				}));
+			}
+			return images;
+		})()
+	`, loadedOnly, includeBase64, maxInlineImageSize, imageFetchTimeout.Milliseconds())
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+
+	images := []mcp.ImageInfo{}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, &images); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal images: %w", err)
+	}
+	return images, nil
+}
+
+// TriggerEvent dispatches a DOM event of eventType on the element matched
+// by selector, or on window or document when selector is "window" or
+// "document". The event class follows the type (MouseEvent, KeyboardEvent,
+// InputEvent, FocusEvent), falling back to CustomEvent when eventInit has a
+// detail and Event otherwise. Events bubble unless eventInit says not to.
+func (c *Controller) TriggerEvent(ctx context.Context, tabID int, selector string, eventType string, eventInit map[string]any) error {
+	if eventType == "" {
+		return fmt.Errorf("eventType is required")
+	}
+	if eventInit == nil {
+		eventInit = map[string]any{}
+	}
+	initJSON, err := json.Marshal(eventInit)
+	if err != nil {
+		return fmt.Errorf("invalid eventInit: %w", err)
+	}
+
+	target := querySelectorJS(selector)
+	switch selector {
+	case "window":
+		target = "window"
+	case "document":
+		target = "document"
+	}
+
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			const type = %q;
+			const init = { bubbles: true, cancelable: true, ...%s };
+			const classes = [
+				[/^(click|dblclick|auxclick|contextmenu|mouse(down|up|move|over|out|enter|leave))$/, MouseEvent],
+				[/^key(down|up|press)$/, KeyboardEvent],
+				[/^(before)?input$/, InputEvent],
+				[/^(focus|blur|focusin|focusout)$/, FocusEvent]
+			];
+			const match = classes.find(([pattern]) => pattern.test(type));
+			const EventClass = match ? match[1] : ('detail' in init ? CustomEvent : Event);
+			const delivered = el.dispatchEvent(new EventClass(type, init));
+			return { dispatched: true, eventClass: EventClass.name, defaultPrevented: !delivered };
+		})()
+	`, target, eventType, initJSON)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// GetTableData extracts the table matched by selector as headers and rows.
+// Header rows are those in <thead>, or leading rows made only of <th> cells.
+func (c *Controller) GetTableData(ctx context.Context, tabID int, selector string) (*mcp.TableData, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			const table = %s;
+			if (!table) return { error: 'Element not found', code: 'not_found' };
+			if (table.tagName !== 'TABLE') return { error: 'Element is not a table: ' + table.tagName, code: 'wrong_type' };
+
+			// Lay the cells out on a grid, repeating spanned cells
+			const grid = [];
+			const rows = Array.from(table.rows);
+			rows.forEach((row, r) => {
+				grid[r] = grid[r] || [];
+				let col = 0;
+				for (const cell of row.cells) {
+					while (grid[r][col] !== undefined) col++;
+					const text = cell.innerText.replace(/\s+/g, ' ').trim();
+					for (let dr = 0; dr < Math.max(cell.rowSpan, 1) && r + dr < rows.length; dr++) {
+						grid[r + dr] = grid[r + dr] || [];
+						for (let dc = 0; dc < Math.max(cell.colSpan, 1); dc++) {
+							grid[r + dr][col + dc] = text;
+						}
+					}
+					col += Math.max(cell.colSpan, 1);
+				}
+			});
+
+			let headerCount = 0;
+			while (headerCount < rows.length) {
+				const row = rows[headerCount];
+				const inHead = row.parentElement.tagName === 'THEAD';
+				const allTh = row.cells.length > 0 && Array.from(row.cells).every(c => c.tagName === 'TH');
+				if (!inHead && !allTh) break;
+				headerCount++;
+			}
+
+			const width = Math.max(0, ...grid.map(r => r.length));
+			const normalize = (r) => Array.from({ length: width }, (_, i) => r[i] ?? '');
+			const headers = Array.from({ length: headerCount ? width : 0 }, (_, i) =>
+				[...new Set(grid.slice(0, headerCount).map(r => r[i] ?? '').filter(Boolean))].join(' / '));
+			return { headers, rows: grid.slice(headerCount).map(normalize) };
+		})()
+	`, querySelectorJS(selector))
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	table := &mcp.TableData{}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, table); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal table: %w", err)
+	}
+	return table, nil
+}
+
+// GetDropdownOptions returns the options of the select element matched by
+// selector. Returns ErrWrongType if the element is not a select.
+func (c *Controller) GetDropdownOptions(ctx context.Context, tabID int, selector string) ([]mcp.DropdownOption, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			if (el.tagName !== 'SELECT') return { error: 'Element is not a select: ' + el.tagName, code: 'wrong_type' };
+			return Array.from(el.options, o => ({
+				value: o.value,
+				text: o.text.trim(),
+				selected: o.selected,
+				disabled: o.disabled
+			}));
+		})()
+	`, querySelectorJS(selector))
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	options := []mcp.DropdownOption{}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, &options); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal options: %w", err)
+	}
+	return options, nil
+}
+
+// GetSelectedOption returns the first selected option of the select
+// element matched by selector, or nil if none is selected.
+func (c *Controller) GetSelectedOption(ctx context.Context, tabID int, selector string) (*mcp.DropdownOption, error) {
+	options, err := c.GetDropdownOptions(ctx, tabID, selector)
+	if err != nil {
+		return nil, err
+	}
+	for i := range options {
+		if options[i].Selected {
+			return &options[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GetVideoInfo returns the playback state of the video element matched by
+// selector. Width and Height are the video's intrinsic dimensions.
+func (c *Controller) GetVideoInfo(ctx context.Context, tabID int, selector string) (*mcp.VideoInfo, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			if (!(el instanceof HTMLVideoElement)) return { error: 'Element is not a video: ' + el.tagName, code: 'wrong_type' };
+			const buffered = [];
+			for (let i = 0; i < el.buffered.length; i++) {
+				buffered.push({ start: el.buffered.start(i), end: el.buffered.end(i) });
+			}
+			return {
+				src: el.currentSrc || el.src,
+				currentTime: el.currentTime,
+				duration: Number.isFinite(el.duration) ? el.duration : 0,
+				paused: el.paused,
+				muted: el.muted,
+				volume: el.volume,
+				readyState: el.readyState,
+				networkState: el.networkState,
+				width: el.videoWidth,
+				height: el.videoHeight,
+				buffered
+			};
+		})()
+	`, querySelectorJS(selector))
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	info := &mcp.VideoInfo{}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal video info: %w", err)
+	}
+	return info, nil
+}
+
+// ControlVideo plays, pauses, mutes or unmutes the video element matched by
+// selector. Play can fail if the browser's autoplay policy blocks it.
+func (c *Controller) ControlVideo(ctx context.Context, tabID int, selector, action string) error {
+	switch action {
+	case "play", "pause", "mute", "unmute":
+	default:
+		return fmt.Errorf("invalid action %q: must be play, pause, mute or unmute", action)
+	}
+
+	script := fmt.Sprintf(`
+		(async () => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			if (!(el instanceof HTMLVideoElement)) return { error: 'Element is not a video: ' + el.tagName, code: 'wrong_type' };
+			switch (%q) {
+				case 'play':
+					try {
+						await el.play();
+					} catch (e) {
+						return { error: 'Playback failed: ' + e.message };
+					}
+					break;
+				case 'pause': el.pause(); break;
+				case 'mute': el.muted = true; break;
+				case 'unmute': el.muted = false; break;
+			}
+			return { paused: el.paused, muted: el.muted };
+		})()
+	`, querySelectorJS(selector), action)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// GetPerformanceMetrics returns the page's navigation and paint timings.
+func (c *Controller) GetPerformanceMetrics(ctx context.Context, tabID int) (*mcp.PerformanceMetrics, error) {
+	script := `
+		new Promise((resolve) => {
+			const nav = performance.getEntriesByType('navigation')[0];
+			const timing = performance.timing;
+			const since = (value) => value > 0 ? value - timing.navigationStart : 0;
+			const fcp = performance.getEntriesByName('first-contentful-paint')[0];
+			const metrics = {
+				navigationStart: performance.timeOrigin,
+				domContentLoaded: nav ? nav.domContentLoadedEventEnd : since(timing.domContentLoadedEventEnd),
+				loadEvent: nav ? nav.loadEventEnd : since(timing.loadEventEnd),
+				firstContentfulPaint: fcp ? fcp.startTime : 0,
+				largestContentfulPaint: 0,
+				timeToFirstByte: nav ? nav.responseStart : since(timing.responseStart),
+				domInteractive: nav ? nav.domInteractive : since(timing.domInteractive),
+				transferSize: nav ? nav.transferSize : 0
+			};
+			// LCP entries are only exposed to buffered observers
+			try {
+				const observer = new PerformanceObserver((list) => {
+					const entries = list.getEntries();
+					metrics.largestContentfulPaint = entries[entries.length - 1].startTime;
+				});
+				observer.observe({ type: 'largest-contentful-paint', buffered: true });
+				setTimeout(() => {
+					observer.disconnect();
+					resolve(metrics);
+				}, 0);
+			} catch (e) {
+				resolve(metrics);
+			}
+		})
+	`
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &mcp.PerformanceMetrics{}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, metrics); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal performance metrics: %w", err)
+	}
+	return metrics, nil
+}
+
+// GetCanvasData returns the contents of the canvas matched by selector as
+// a data URL in format "png" (default) or "jpeg". Returns ErrWrongType for
+// non-canvas elements and ErrCrossOrigin for canvases tainted by
+// cross-origin images.
+func (c *Controller) GetCanvasData(ctx context.Context, tabID int, selector string, format string) (string, error) {
+	switch format {
+	case "":
+		format = "png"
+	case "png", "jpeg":
+	default:
+		return "", fmt.Errorf("invalid format %q: must be png or jpeg", format)
+	}
+
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			if (el.tagName !== 'CANVAS') return { error: 'Element is not a canvas: ' + el.tagName, code: 'wrong_type' };
+			try {
+				return { dataUrl: el.toDataURL('image/' + %q) };
+			} catch (e) {
+				if (e.name === 'SecurityError') {
+					return { error: 'Canvas is tainted by cross-origin data and cannot be read', code: 'cross_origin' };
+				}
+				return { error: e.message };
+			}
+		})()
+	`, querySelectorJS(selector), format)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return "", err
+	}
+	if err := resultError(result); err != nil {
+		return "", err
+	}
+	m, _ := result.(map[string]any)
+	dataURL, _ := m["dataUrl"].(string)
+	return dataURL, nil
+}
+
+// lighthouseCategories are the Lighthouse categories RunLighthouse accepts.
+var lighthouseCategories = map[string]bool{
+	"performance":    true,
+	"accessibility":  true,
+	"best-practices": true,
+	"seo":            true,
+}
+
+// RunLighthouse audits a tab for the given categories, or all of them when
+// categories is empty. Lighthouse itself cannot run in the extension, so
+// the extension computes the subset of its audits that can be read from the
+// loaded page and scores them the way Lighthouse does: performance from
+// first and largest contentful paint and layout shift, the other categories
+// as the share of their audits that pass. The diagnostics hold every
+// audit's outcome, the timings and the DevTools Protocol's Performance
+// metrics. Performance requires the DevTools Protocol and otherwise fails
+// with ErrUnsupported.
+func (c *Controller) RunLighthouse(ctx context.Context, tabID int, categories []string) (*mcp.LighthouseResult, error) {
+	for _, category := range categories {
+		if !lighthouseCategories[category] {
+			return nil, fmt.Errorf("invalid category %q: must be performance, accessibility, best-practices or seo", category)
+		}
+	}
+
+	resp, err := c.send(ctx, "browser.lighthouse.run", map[string]any{
+		"tabId":      tabID,
+		"categories": categories,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, responseError(resp.Error)
+	}
+
+	var result mcp.LighthouseResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal lighthouse result: %w", err)
+	}
+	return &result, nil
+}
+
 // GetTools returns the list of available tools.
 func (c *Controller) GetTools() []mcp.Tool {
 	return mcp.GetTools()
@@ -0,0 +1,257 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+func TestEvaluateXPathResultType(t *testing.T) {
+	tests := []struct {
+		expression string
+		resultType string
+		constant   string
+	}{
+		{"count(//a)", "number", "XPathResult.NUMBER_TYPE"},
+		{" sum(//td) ", "number", "XPathResult.NUMBER_TYPE"},
+		{"string(//h1)", "string", "XPathResult.STRING_TYPE"},
+		{"normalize-space(//title)", "string", "XPathResult.STRING_TYPE"},
+		{"contains(//h1, 'x')", "boolean", "XPathResult.BOOLEAN_TYPE"},
+		{"not(//form)", "boolean", "XPathResult.BOOLEAN_TYPE"},
+		{"//a[@href]", "nodes", "XPathResult.ORDERED_NODE_SNAPSHOT_TYPE"},
+		{"(//li)[1]", "nodes", "XPathResult.ORDERED_NODE_SNAPSHOT_TYPE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			var script string
+			c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+				script = params.(map[string]any)["script"].(string)
+				return scriptResult(t, map[string]any{"value": 1}), nil
+			}))
+
+			result, err := c.EvaluateXPath(context.Background(), 1, tt.expression, "")
+			if err != nil {
+				t.Fatalf("EvaluateXPath: %v", err)
+			}
+			if result.ResultType != tt.resultType {
+				t.Errorf("ResultType = %q, want %q", result.ResultType, tt.resultType)
+			}
+			if !strings.Contains(script, tt.constant+",") {
+				t.Errorf("script does not request %s:\n%s", tt.constant, script)
+			}
+		})
+	}
+}
+
+func TestFocusAndBlurElement(t *testing.T) {
+	ops := map[string]func(c *Controller, selector string) error{
+		"focus": func(c *Controller, selector string) error {
+			return c.FocusElement(context.Background(), 1, selector)
+		},
+		"blur": func(c *Controller, selector string) error {
+			return c.BlurElement(context.Background(), 1, selector)
+		},
+	}
+	for name, op := range ops {
+		t.Run(name, func(t *testing.T) {
+			var script string
+			c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+				script = params.(map[string]any)["script"].(string)
+				return scriptResult(t, map[string]any{"tagName": "INPUT"}), nil
+			}))
+			if err := op(c, `input[name="q"]`); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if want := `document.querySelector("input[name=\"q\"]")`; !strings.Contains(script, want) {
+				t.Errorf("script does not contain %s:\n%s", want, script)
+			}
+
+			c = NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+				return scriptResult(t, map[string]any{"error": "Element not found", "code": "not_found"}), nil
+			}))
+			if err := op(c, "#missing"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("err = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestListTabsMutedInfo(t *testing.T) {
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		return &mcp.Message{Result: json.RawMessage(`[
+			{"id": 1, "url": "https://a.example", "audible": true, "mutedInfo": {"muted": false}},
+			{"id": 2, "url": "https://b.example", "mutedInfo": {"muted": true, "reason": "extension", "extensionId": "abc"}},
+			{"id": 3, "url": "https://c.example"}
+		]`)}, nil
+	}))
+
+	tabs, err := c.ListTabs(context.Background())
+	if err != nil {
+		t.Fatalf("ListTabs: %v", err)
+	}
+	want := []*mcp.MutedInfo{
+		{Muted: false},
+		{Muted: true, Reason: "extension", ExtensionID: "abc"},
+		nil,
+	}
+	if len(tabs) != len(want) {
+		t.Fatalf("got %d tabs, want %d", len(tabs), len(want))
+	}
+	for i, tab := range tabs {
+		if !reflect.DeepEqual(tab.MutedInfo, want[i]) {
+			t.Errorf("tab %d MutedInfo = %+v, want %+v", tab.ID, tab.MutedInfo, want[i])
+		}
+	}
+	if !tabs[0].Audible {
+		t.Errorf("tab 1 Audible = false, want true")
+	}
+}
+
+func TestFillByLabelUsesOneScript(t *testing.T) {
+	var scripts []string
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		scripts = append(scripts, params.(map[string]any)["script"].(string))
+		return scriptResult(t, map[string]any{"filled": true, "tagName": "INPUT"}), nil
+	}))
+
+	if err := c.FillByLabel(context.Background(), 1, "Email", "a@example.com"); err != nil {
+		t.Fatalf("FillByLabel: %v", err)
+	}
+	if len(scripts) != 1 {
+		t.Fatalf("ran %d scripts, want 1", len(scripts))
+	}
+	for _, want := range []string{`labelControl("Email")`, `el.value = "a@example.com"`} {
+		if !strings.Contains(scripts[0], want) {
+			t.Errorf("script does not contain %s:\n%s", want, scripts[0])
+		}
+	}
+
+	c = NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		return scriptResult(t, map[string]any{"error": "No label matching: Email", "code": "not_found"}), nil
+	}))
+	if err := c.FillByLabel(context.Background(), 1, "Email", "x"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetVisibleTextChecksComputedVisibility(t *testing.T) {
+	var script string
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		script = params.(map[string]any)["script"].(string)
+		return scriptResult(t, []string{"Header", "Body"}), nil
+	}))
+
+	text, err := c.GetVisibleText(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetVisibleText: %v", err)
+	}
+	if text != "Header\nBody" {
+		t.Errorf("text = %q, want the nodes joined by newlines", text)
+	}
+	// offsetParent is null for position: fixed elements, which are visible
+	if strings.Contains(script, "offsetParent") || !strings.Contains(script, "checkVisibility") {
+		t.Errorf("script does not check visibility with checkVisibility:\n%s", script)
+	}
+}
+
+func TestGetImagesBoundsDownloads(t *testing.T) {
+	var script string
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		script = params.(map[string]any)["script"].(string)
+		return scriptResult(t, []map[string]any{{"src": "https://example.com/a.png", "data": "data:image/png;base64,AAAA"}}), nil
+	}))
+
+	images, err := c.GetImages(context.Background(), 1, false, true)
+	if err != nil {
+		t.Fatalf("GetImages: %v", err)
+	}
+	if len(images) != 1 || images[0].Data != "data:image/png;base64,AAAA" {
+		t.Errorf("images = %+v", images)
+	}
+	for _, want := range []string{"maxSize = 102400", "fetchTimeout = 10000", "controller.abort()", "Content-Length"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script does not contain %s:\n%s", want, script)
+		}
+	}
+}
+
+func TestRunLighthouse(t *testing.T) {
+	var sent map[string]any
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		if method != "browser.lighthouse.run" {
+			t.Errorf("method = %s, want browser.lighthouse.run", method)
+		}
+		sent = params.(map[string]any)
+		return &mcp.Message{Result: json.RawMessage(`{"performance": 0, "accessibility": 86, "bestPractices": 0, "seo": 50,
+			"diagnostics": {"audits": {"seo": {"meta-description": {"passed": false, "failures": 1}}}}}`)}, nil
+	}))
+
+	result, err := c.RunLighthouse(context.Background(), 1, []string{"accessibility", "seo"})
+	if err != nil {
+		t.Fatalf("RunLighthouse: %v", err)
+	}
+	if result.Accessibility != 86 || result.SEO != 50 || result.Diagnostics["audits"] == nil {
+		t.Errorf("result = %+v", result)
+	}
+	if !reflect.DeepEqual(sent["categories"], []string{"accessibility", "seo"}) {
+		t.Errorf("categories = %v", sent["categories"])
+	}
+
+	if _, err := c.RunLighthouse(context.Background(), 1, []string{"pwa"}); err == nil {
+		t.Error("RunLighthouse accepted the unknown category pwa")
+	}
+}
+
+func TestBatchTabOpStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls atomic.Int32
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		calls.Add(1)
+		cancel()
+		return &mcp.Message{Result: json.RawMessage(`null`)}, nil
+	}), WithBatchConcurrency(1))
+
+	ops := make([]mcp.TabOp, 5)
+	for i := range ops {
+		ops[i] = mcp.TabOp{TabID: i + 1, Type: "close"}
+	}
+	results, err := c.BatchTabOp(ctx, ops)
+	if err != nil {
+		t.Fatalf("BatchTabOp: %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("%d operations reached the extension after cancellation, want 1", n)
+	}
+	if results[0].Error != "" {
+		t.Errorf("results[0].Error = %q, want none", results[0].Error)
+	}
+	for _, res := range results[1:] {
+		if res.Error != context.Canceled.Error() || res.TabID == 0 || res.Type != "close" {
+			t.Errorf("result = %+v, want a context canceled error", res)
+		}
+	}
+}
+
+func TestResolveTabIDCrossesSlashes(t *testing.T) {
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		return &mcp.Message{Result: json.RawMessage(`[
+			{"id": 1, "url": "https://example.org/a/b"},
+			{"id": 2, "url": "https://example.com/a/b"}
+		]`)}, nil
+	}))
+
+	id, err := c.ResolveTabID(context.Background(), "https://example.com/*")
+	if err != nil || id != 2 {
+		t.Errorf("ResolveTabID = %d, %v, want 2", id, err)
+	}
+	if _, err := c.ResolveTabID(context.Background(), "https://example.com/["); err == nil {
+		t.Error("ResolveTabID accepted a malformed pattern")
+	}
+}
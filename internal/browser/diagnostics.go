@@ -0,0 +1,278 @@
+// Package browser implements access to diagnostics captured in the page.
+// Console and error capture is installed by the extension's page-hooks.js
+// content script; network capture is injected on demand.
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// GetConsoleLogs returns console messages captured in a tab, optionally
+// filtered by level and limited to the most recent entries. Returned entries
+// are removed from the page's buffer.
+func (c *Controller) GetConsoleLogs(ctx context.Context, tabID int, level string, limit int) ([]mcp.ConsoleLog, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			const buffer = window.__mcpConsoleLogs || [];
+			let selected = buffer.filter(e => !%q || e.level === %q);
+			if (%d > 0) selected = selected.slice(-%d);
+			window.__mcpConsoleLogs = buffer.filter(e => !selected.includes(e));
+			return selected;
+		})()
+	`, level, level, limit, limit)
+
+	result, err := c.executeScriptInWorld(ctx, tabID, script, "MAIN")
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(result)
+	var logs []mcp.ConsoleLog
+	if err := json.Unmarshal(data, &logs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal console logs: %w", err)
+	}
+	return logs, nil
+}
+
+// GetPageErrors returns uncaught errors and unhandled promise rejections
+// captured in a tab, optionally clearing them.
+func (c *Controller) GetPageErrors(ctx context.Context, tabID int, clearAfterRead bool) ([]mcp.PageError, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			const errors = window.__mcpPageErrors || [];
+			if (%t) window.__mcpPageErrors = [];
+			return errors;
+		})()
+	`, clearAfterRead)
+
+	result, err := c.executeScriptInWorld(ctx, tabID, script, "MAIN")
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(result)
+	var errs []mcp.PageError
+	if err := json.Unmarshal(data, &errs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal page errors: %w", err)
+	}
+	return errs, nil
+}
+
+// networkHookJS wraps window.fetch and XMLHttpRequest so that calls made by
+// the page are recorded into window.__mcpNetworkLog, and completed responses
+// are passed to the functions in window.__mcpResponseListeners as
+// { url, status, headers, read } where read() resolves to { body, isBase64 }.
+// The number of calls in flight is kept in window.__mcpInFlight and the
+// time of the last call starting or finishing in window.__mcpNetworkChange.
+// It is idempotent.
+const networkHookJS = `
+	if (!window.__mcpNetworkLog) {
+		window.__mcpNetworkLog = [];
+		window.__mcpResponseListeners = new Set();
+		window.__mcpInFlight = 0;
+		window.__mcpNetworkChange = Date.now();
+		const track = (delta) => {
+			window.__mcpInFlight += delta;
+			window.__mcpNetworkChange = Date.now();
+		};
+		const notify = (response) => {
+			window.__mcpResponseListeners.forEach(fn => { try { fn(response); } catch (e) {} });
+		};
+		const isText = (type) => /^text\/|json|xml|javascript|x-www-form-urlencoded/.test(type || '');
+		const base64 = (buffer) => {
+			const bytes = new Uint8Array(buffer);
+			let binary = '';
+			for (let i = 0; i < bytes.length; i += 0x8000) {
+				binary += String.fromCharCode.apply(null, bytes.subarray(i, i + 0x8000));
+			}
+			return btoa(binary);
+		};
+		const truncate = (v) => v == null ? '' : String(v).slice(0, 1024);
+		const absolute = (u) => {
+			try { return new URL(String(u), location.href).href; } catch (e) { return String(u); }
+		};
+		const record = (entry) => {
+			window.__mcpNetworkLog.push(entry);
+			if (window.__mcpNetworkLog.length > 500) window.__mcpNetworkLog.shift();
+		};
+
+		const originalFetch = window.fetch;
+		window.fetch = async function (input, init) {
+			const isRequest = input instanceof Request;
+			const entry = {
+				url: absolute(isRequest ? input.url : input),
+				method: ((init && init.method) || (isRequest && input.method) || 'GET').toUpperCase(),
+				status: 0,
+				requestBody: truncate(init && init.body),
+				responseBody: '',
+				timestamp: Date.now()
+			};
+			record(entry);
+			track(1);
+			let response;
+			try {
+				response = await originalFetch.apply(this, arguments);
+			} finally {
+				track(-1);
+			}
+			entry.status = response.status;
+			response.clone().text().then(t => { entry.responseBody = truncate(t); }).catch(() => {});
+			if (window.__mcpResponseListeners.size > 0) {
+				const copy = response.clone();
+				const headers = {};
+				response.headers.forEach((value, name) => { headers[name] = value; });
+				notify({
+					url: entry.url,
+					status: response.status,
+					headers,
+					read: async () => {
+						if (isText(headers['content-type'])) return { body: await copy.text(), isBase64: false };
+						return { body: base64(await copy.arrayBuffer()), isBase64: true };
+					}
+				});
+			}
+			return response;
+		};
+
+		const open = XMLHttpRequest.prototype.open;
+		const send = XMLHttpRequest.prototype.send;
+		XMLHttpRequest.prototype.open = function (method, url) {
+			this.__mcpEntry = { url: absolute(url), method: String(method).toUpperCase(), status: 0, requestBody: '', responseBody: '', timestamp: 0 };
+			return open.apply(this, arguments);
+		};
+		XMLHttpRequest.prototype.send = function (body) {
+			const entry = this.__mcpEntry;
+			const result = send.apply(this, arguments);
+			if (entry) {
+				entry.requestBody = truncate(body);
+				entry.timestamp = Date.now();
+				record(entry);
+				track(1);
+				const finish = () => {
+					track(-1);
+					entry.status = this.status;
+					if (this.responseType === '' || this.responseType === 'text') {
+						entry.responseBody = truncate(this.responseText);
+					}
+					if (window.__mcpResponseListeners.size === 0) return;
+					const headers = {};
+					this.getAllResponseHeaders().trim().split(/[\r\n]+/).forEach(line => {
+						const i = line.indexOf(':');
+						if (i > 0) headers[line.slice(0, i).trim().toLowerCase()] = line.slice(i + 1).trim();
+					});
+					const xhr = this;
+					notify({
+						url: entry.url,
+						status: this.status,
+						headers,
+						read: async () => {
+							switch (xhr.responseType) {
+								case '':
+								case 'text':
+									return { body: xhr.responseText, isBase64: false };
+								case 'json':
+									return { body: JSON.stringify(xhr.response), isBase64: false };
+								case 'document':
+									return { body: xhr.response ? xhr.response.documentElement.outerHTML : '', isBase64: false };
+								case 'blob':
+									return { body: base64(await xhr.response.arrayBuffer()), isBase64: true };
+								default:
+									return { body: base64(xhr.response), isBase64: true };
+							}
+						}
+					});
+				};
+				// A synchronous request has already finished
+				if (this.readyState === XMLHttpRequest.DONE) finish();
+				else this.addEventListener('loadend', finish);
+			}
+			return result;
+		};
+	}
+`
+
+// MonitorNetworkRequests records the XHR and fetch calls a tab makes during
+// the given duration, at most 25 seconds, and returns those whose URL
+// contains urlPattern. Requests started before monitoring began are not
+// included.
+func (c *Controller) MonitorNetworkRequests(ctx context.Context, tabID int, durationMs int, urlPattern string) ([]mcp.NetworkRequest, error) {
+	durationMs = min(durationMs, maxAsyncScriptTimeout)
+	script := fmt.Sprintf(`
+		new Promise((resolve) => {
+			%s
+			const start = Date.now();
+			setTimeout(() => {
+				const pattern = %q;
+				resolve(window.__mcpNetworkLog.filter(e =>
+					e.timestamp >= start && (!pattern || e.url.includes(pattern))));
+			}, %d);
+		})
+	`, networkHookJS, urlPattern, durationMs)
+
+	result, err := c.executeScriptInWorld(ctx, tabID, script, "MAIN")
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(result)
+	var requests []mcp.NetworkRequest
+	if err := json.Unmarshal(data, &requests); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal network requests: %w", err)
+	}
+	return requests, nil
+}
+
+// InterceptResponse waits up to timeoutMs, at most 25000, for the page to
+// complete an XHR or fetch call whose URL contains urlPattern and returns
+// its response. Binary bodies are base64-encoded. Returns ErrTimeout if
+// none completes.
+func (c *Controller) InterceptResponse(ctx context.Context, tabID int, urlPattern string, timeoutMs int) (*mcp.InterceptedResponse, error) {
+	timeoutMs = min(timeoutMs, maxAsyncScriptTimeout)
+	script := fmt.Sprintf(`
+		new Promise((resolve) => {
+			%s
+			const pattern = %q;
+			const listener = async (response) => {
+				if (pattern && !response.url.includes(pattern)) return;
+				finish();
+				try {
+					const { body, isBase64 } = await response.read();
+					resolve({ url: response.url, status: response.status, headers: response.headers, body, isBase64, timestamp: Date.now() });
+				} catch (e) {
+					resolve({ error: 'Failed to read response body: ' + e.message });
+				}
+			};
+			const finish = () => {
+				window.__mcpResponseListeners.delete(listener);
+				clearTimeout(timer);
+			};
+			const timer = setTimeout(() => {
+				finish();
+				resolve({ timeout: true });
+			}, %d);
+			window.__mcpResponseListeners.add(listener);
+		})
+	`, networkHookJS, urlPattern, timeoutMs)
+
+	result, err := c.executeScriptInWorld(ctx, tabID, script, "MAIN")
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+	if m, ok := result.(map[string]any); ok && m["timeout"] == true {
+		return nil, fmt.Errorf("no response matching %q: %w", urlPattern, ErrTimeout)
+	}
+
+	data, _ := json.Marshal(result)
+	var response mcp.InterceptedResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &response, nil
+}
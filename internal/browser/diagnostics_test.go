@@ -0,0 +1,39 @@
+package browser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+func TestMonitorNetworkRequestsCapsDuration(t *testing.T) {
+	var script string
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		script = params.(map[string]any)["script"].(string)
+		return scriptResult(t, []any{}), nil
+	}))
+
+	if _, err := c.MonitorNetworkRequests(context.Background(), 1, 60000, ""); err != nil {
+		t.Fatalf("MonitorNetworkRequests: %v", err)
+	}
+	if !strings.Contains(script, "}, 25000);") {
+		t.Errorf("script does not record for 25000ms:\n%s", script)
+	}
+}
+
+func TestInterceptResponseCapsTimeout(t *testing.T) {
+	var script string
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		script = params.(map[string]any)["script"].(string)
+		return scriptResult(t, map[string]any{"url": "https://example.com/api", "status": 200}), nil
+	}))
+
+	if _, err := c.InterceptResponse(context.Background(), 1, "/api", 60000); err != nil {
+		t.Fatalf("InterceptResponse: %v", err)
+	}
+	if !strings.Contains(script, "}, 25000);") {
+		t.Errorf("script does not wait 25000ms:\n%s", script)
+	}
+}
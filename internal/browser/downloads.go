@@ -0,0 +1,59 @@
+// Package browser implements download management via the extension.
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// ListDownloads returns downloads matching query, most recent first.
+// A limit of 0 returns all matches.
+func (c *Controller) ListDownloads(ctx context.Context, query string, limit int) ([]mcp.Download, error) {
+	resp, err := c.send(ctx, "browser.downloads.search", map[string]any{
+		"query": query,
+		"limit": limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	var downloads []mcp.Download
+	if err := json.Unmarshal(resp.Result, &downloads); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal downloads: %w", err)
+	}
+	return downloads, nil
+}
+
+// OpenDownload opens a completed download with the system handler.
+func (c *Controller) OpenDownload(ctx context.Context, downloadID int) error {
+	return c.downloadAction(ctx, "browser.downloads.open", downloadID)
+}
+
+// CancelDownload cancels an in-progress download.
+func (c *Controller) CancelDownload(ctx context.Context, downloadID int) error {
+	return c.downloadAction(ctx, "browser.downloads.cancel", downloadID)
+}
+
+// EraseDownload removes a download from the browser's history.
+func (c *Controller) EraseDownload(ctx context.Context, downloadID int) error {
+	return c.downloadAction(ctx, "browser.downloads.erase", downloadID)
+}
+
+func (c *Controller) downloadAction(ctx context.Context, method string, downloadID int) error {
+	resp, err := c.send(ctx, method, map[string]any{
+		"downloadId": downloadID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+// Package browser implements page emulation overrides.
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// EmulateGeolocation makes navigator.geolocation in a tab report the given
+// position. The extension keeps the override in session storage and
+// installs it at document_start of every navigation in the tab, before page
+// scripts run, until ClearGeolocation is called or the tab is closed.
+func (c *Controller) EmulateGeolocation(ctx context.Context, tabID int, latitude, longitude, accuracy float64) error {
+	resp, err := c.send(ctx, "browser.geolocation.set", map[string]any{
+		"tabId":     tabID,
+		"latitude":  latitude,
+		"longitude": longitude,
+		"accuracy":  accuracy,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	c.setEmulation(tabID, "geolocation", fmt.Sprintf("%g,%g", latitude, longitude))
+	return nil
+}
+
+// MockGeolocationTrack makes navigator.geolocation in a tab move through
+// positions, advancing every intervalMs and looping after the last one.
+// Watchers registered with watchPosition receive each update. Playback is
+// re-applied on navigation like EmulateGeolocation and stops on
+// ClearGeolocation.
+func (c *Controller) MockGeolocationTrack(ctx context.Context, tabID int, positions []mcp.GeoPosition, intervalMs int) error {
+	if len(positions) == 0 {
+		return fmt.Errorf("positions must not be empty")
+	}
+	if intervalMs <= 0 {
+		return fmt.Errorf("intervalMs must be positive")
+	}
+
+	resp, err := c.send(ctx, "browser.geolocation.track", map[string]any{
+		"tabId":      tabID,
+		"positions":  positions,
+		"intervalMs": intervalMs,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	c.setEmulation(tabID, "geolocation", fmt.Sprintf("track of %d positions", len(positions)))
+	return nil
+}
+
+// ClearGeolocation removes a tab's geolocation override.
+func (c *Controller) ClearGeolocation(ctx context.Context, tabID int) error {
+	resp, err := c.send(ctx, "browser.geolocation.clear", map[string]any{
+		"tabId": tabID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	c.setEmulation(tabID, "geolocation", "")
+	return nil
+}
+
+// EmulateDevice overrides the user agent, viewport dimensions and pixel
+// ratio that page scripts observe. Zero fields are left unchanged. This
+// does not resize the actual layout viewport and lasts until navigation.
+func (c *Controller) EmulateDevice(ctx context.Context, tabID int, device mcp.DeviceProfile) error {
+	script := fmt.Sprintf(`
+		(() => {
+			const override = (obj, prop, value) => {
+				if (value) Object.defineProperty(obj, prop, { get: () => value, configurable: true });
+			};
+			override(navigator, 'userAgent', %q);
+			override(window, 'innerWidth', %d);
+			override(window, 'outerWidth', %d);
+			override(screen, 'width', %d);
+			override(window, 'innerHeight', %d);
+			override(window, 'outerHeight', %d);
+			override(screen, 'height', %d);
+			override(window, 'devicePixelRatio', %g);
+			override(navigator, 'maxTouchPoints', %t ? 5 : 0);
+			window.dispatchEvent(new Event('resize'));
+			return true;
+		})()
+	`, device.UserAgent,
+		device.Width, device.Width, device.Width,
+		device.Height, device.Height, device.Height,
+		device.DevicePixelRatio, device.Mobile)
+
+	result, err := c.executeScriptInWorld(ctx, tabID, script, "MAIN")
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// EmulateTimezone makes Intl.DateTimeFormat and Date in a tab use the given
+// IANA timezone, such as "America/New_York". Only the default formatting
+// zone and Date.prototype.getTimezoneOffset are patched, and the override
+// lasts until navigation.
+func (c *Controller) EmulateTimezone(ctx context.Context, tabID int, timezone string) error {
+	if timezone == "" || timezone == "Local" {
+		return fmt.Errorf("invalid timezone %q: an IANA timezone name is required", timezone)
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q: not a recognized IANA timezone", timezone)
+	}
+
+	script := fmt.Sprintf(`
+		(() => {
+			const timeZone = %q;
+			const Native = window.__mcpNativeDateTimeFormat || Intl.DateTimeFormat;
+			window.__mcpNativeDateTimeFormat = Native;
+
+			function DateTimeFormat(locales, options) {
+				return new Native(locales, { timeZone, ...options });
+			}
+			DateTimeFormat.prototype = Native.prototype;
+			DateTimeFormat.supportedLocalesOf = Native.supportedLocalesOf;
+			Intl.DateTimeFormat = DateTimeFormat;
+
+			const parts = new Native('en-US', {
+				timeZone, hourCycle: 'h23',
+				year: 'numeric', month: 'numeric', day: 'numeric',
+				hour: 'numeric', minute: 'numeric', second: 'numeric',
+			});
+			const getTime = Date.prototype.getTime;
+			Date.prototype.getTimezoneOffset = function () {
+				const ms = getTime.call(this);
+				if (isNaN(ms)) return NaN;
+				const p = {};
+				for (const { type, value } of parts.formatToParts(ms)) p[type] = Number(value);
+				const wall = Date.UTC(p.year, p.month - 1, p.day, p.hour, p.minute, p.second);
+				return Math.round((ms - ms %% 1000 - wall) / 60000);
+			};
+			return true;
+		})()
+	`, timezone)
+
+	result, err := c.executeScriptInWorld(ctx, tabID, script, "MAIN")
+	if err != nil {
+		return err
+	}
+	return resultError(result)
+}
+
+// EmulateMedia sets the CSS media type the tab renders with: "print",
+// "screen" or "" to reset. It uses the DevTools Protocol where available,
+// keeping the debugger attached until reset. Otherwise print emulation
+// falls back to re-injecting the page's same-origin @media print rules
+// unconditionally, which cannot disable screen-only rules.
+func (c *Controller) EmulateMedia(ctx context.Context, tabID int, media string) error {
+	if media != "" && media != "print" && media != "screen" {
+		return fmt.Errorf("invalid media %q: must be print, screen or empty to reset", media)
+	}
+
+	resp, err := c.send(ctx, "browser.emulation.setMedia", map[string]any{
+		"tabId": tabID,
+		"media": media,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error == nil {
+		c.setEmulation(tabID, "media", media)
+		return nil
+	}
+	if err := responseError(resp.Error); !errors.Is(err, ErrUnsupported) {
+		return err
+	}
+
+	script := fmt.Sprintf(`
+		(() => {
+			document.getElementById('__mcp-emulate-media')?.remove();
+			if (%q !== 'print') return true;
+			const rules = [];
+			const collect = (list) => {
+				for (const rule of list) {
+					if (rule instanceof CSSMediaRule) {
+						if (/\bprint\b/.test(rule.media.mediaText)) {
+							rules.push(...Array.from(rule.cssRules, r => r.cssText));
+						} else {
+							collect(rule.cssRules);
+						}
+					}
+				}
+			};
+			for (const sheet of document.styleSheets) {
+				try { collect(sheet.cssRules); } catch (e) { /* cross-origin sheet */ }
+			}
+			const style = document.createElement('style');
+			style.id = '__mcp-emulate-media';
+			style.textContent = rules.join('\n');
+			document.head.appendChild(style);
+			return true;
+		})()
+	`, media)
+
+	// The injected rules end on navigation, so they are not recorded
+	_, err = c.ExecuteScript(ctx, tabID, script)
+	return err
+}
+
+// EmulateColorScheme sets the prefers-color-scheme media feature of a tab
+// to "dark" or "light", or resets it with "". It requires the DevTools
+// Protocol and returns ErrUnsupported without it.
+func (c *Controller) EmulateColorScheme(ctx context.Context, tabID int, scheme string) error {
+	if scheme != "" && scheme != "dark" && scheme != "light" {
+		return fmt.Errorf("invalid scheme %q: must be dark, light or empty to reset", scheme)
+	}
+
+	resp, err := c.send(ctx, "browser.emulation.setColorScheme", map[string]any{
+		"tabId":  tabID,
+		"scheme": scheme,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return responseError(resp.Error)
+	}
+	c.setEmulation(tabID, "colorScheme", scheme)
+	return nil
+}
+
+// setEmulation records an active emulation for ActiveEmulations; an empty
+// value removes it.
+func (c *Controller) setEmulation(tabID int, kind, value string) {
+	c.emulationMu.Lock()
+	defer c.emulationMu.Unlock()
+
+	if value == "" {
+		delete(c.emulations[tabID], kind)
+		if len(c.emulations[tabID]) == 0 {
+			delete(c.emulations, tabID)
+		}
+		return
+	}
+	if c.emulations == nil {
+		c.emulations = make(map[int]map[string]string)
+	}
+	if c.emulations[tabID] == nil {
+		c.emulations[tabID] = make(map[string]string)
+	}
+	c.emulations[tabID][kind] = value
+}
+
+// ClearEmulations forgets the given kinds of emulation recorded for a tab,
+// or all of them when no kinds are given. It is called when the extension
+// reports that a tab closed or that its debugger session, which carries the
+// media and colorScheme emulations, was detached.
+func (c *Controller) ClearEmulations(tabID int, kinds ...string) {
+	if len(kinds) == 0 {
+		c.emulationMu.Lock()
+		delete(c.emulations, tabID)
+		c.emulationMu.Unlock()
+		return
+	}
+	for _, kind := range kinds {
+		c.setEmulation(tabID, kind, "")
+	}
+}
+
+// ClearAllEmulations forgets the emulations recorded for every tab, as
+// nothing is known about their state once the extension disconnects.
+func (c *Controller) ClearAllEmulations() {
+	c.emulationMu.Lock()
+	defer c.emulationMu.Unlock()
+	c.emulations = nil
+}
+
+// ActiveEmulations returns the persistent emulations (geolocation, media and
+// colorScheme) set through this controller, by tab ID. Script-based
+// emulations that end on navigation, such as the print media fallback, are
+// not included.
+func (c *Controller) ActiveEmulations() map[int]map[string]string {
+	c.emulationMu.Lock()
+	defer c.emulationMu.Unlock()
+
+	active := make(map[int]map[string]string, len(c.emulations))
+	for tabID, kinds := range c.emulations {
+		active[tabID] = make(map[string]string, len(kinds))
+		for kind, value := range kinds {
+			active[tabID][kind] = value
+		}
+	}
+	return active
+}
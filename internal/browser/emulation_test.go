@@ -0,0 +1,72 @@
+package browser
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+func TestEmulateDeviceReportsScriptErrors(t *testing.T) {
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		return scriptResult(t, map[string]any{"error": "Cannot redefine property: userAgent"}), nil
+	}))
+
+	err := c.EmulateDevice(context.Background(), 1, mcp.DeviceProfile{UserAgent: "test", Width: 390})
+	if err == nil || err.Error() != "Cannot redefine property: userAgent" {
+		t.Errorf("err = %v, want the script's error", err)
+	}
+}
+
+func TestEmulateTimezoneReportsScriptErrors(t *testing.T) {
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		return scriptResult(t, map[string]any{"error": "Cannot assign to read only property 'DateTimeFormat'"}), nil
+	}))
+
+	err := c.EmulateTimezone(context.Background(), 1, "America/New_York")
+	if err == nil || err.Error() != "Cannot assign to read only property 'DateTimeFormat'" {
+		t.Errorf("err = %v, want the script's error", err)
+	}
+}
+
+func TestClearEmulations(t *testing.T) {
+	c := NewController(nil)
+	c.setEmulation(1, "media", "print")
+	c.setEmulation(1, "colorScheme", "dark")
+	c.setEmulation(1, "geolocation", "1,2")
+	c.setEmulation(2, "geolocation", "3,4")
+
+	c.ClearEmulations(1, "media", "colorScheme")
+	want := map[int]map[string]string{1: {"geolocation": "1,2"}, 2: {"geolocation": "3,4"}}
+	if got := c.ActiveEmulations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("after debugger detach: %v, want %v", got, want)
+	}
+
+	c.ClearEmulations(2)
+	want = map[int]map[string]string{1: {"geolocation": "1,2"}}
+	if got := c.ActiveEmulations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("after tab close: %v, want %v", got, want)
+	}
+
+	c.ClearAllEmulations()
+	if got := c.ActiveEmulations(); len(got) != 0 {
+		t.Errorf("after disconnect: %v, want none", got)
+	}
+}
+
+func TestEmulateMediaFallbackNotRecorded(t *testing.T) {
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		if method == "browser.emulation.setMedia" {
+			return &mcp.Message{Error: &mcp.Error{Message: "DevTools Protocol not available", Data: map[string]any{"code": "unsupported"}}}, nil
+		}
+		return scriptResult(t, true), nil
+	}))
+
+	if err := c.EmulateMedia(context.Background(), 1, "print"); err != nil {
+		t.Fatalf("EmulateMedia: %v", err)
+	}
+	if got := c.ActiveEmulations(); len(got) != 0 {
+		t.Errorf("ActiveEmulations = %v, want none for the script fallback", got)
+	}
+}
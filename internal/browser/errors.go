@@ -0,0 +1,80 @@
+// Package browser defines the errors returned by controller operations.
+package browser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+var (
+	// ErrNotFound is returned when a selector matches no element.
+	ErrNotFound = errors.New("element not found")
+	// ErrWrongType is returned when an element is not of the kind an
+	// operation requires, e.g. checking something that is not a checkbox.
+	ErrWrongType = errors.New("wrong element type")
+	// ErrCrossOrigin is returned when the same-origin policy blocks access,
+	// e.g. to the document of a cross-origin iframe.
+	ErrCrossOrigin = errors.New("blocked by same-origin policy")
+	// ErrTimeout is returned when a wait operation gives up before its
+	// condition was met.
+	ErrTimeout = errors.New("timed out")
+	// ErrUnsupported is returned when the browser lacks an API an operation
+	// needs, e.g. the DevTools Protocol on Firefox.
+	ErrUnsupported = errors.New("not supported by this browser")
+	// ErrOutOfBounds is returned when coordinates lie outside the captured
+	// viewport.
+	ErrOutOfBounds = errors.New("coordinates out of bounds")
+	// ErrPayloadTooLarge is returned when an input exceeds the size an
+	// operation accepts.
+	ErrPayloadTooLarge = errors.New("payload too large")
+)
+
+// scriptErrorCodes maps the code field of an injected script's error object
+// to the sentinel error it represents.
+var scriptErrorCodes = map[string]error{
+	"not_found":    ErrNotFound,
+	"wrong_type":   ErrWrongType,
+	"cross_origin": ErrCrossOrigin,
+	"unsupported":  ErrUnsupported,
+}
+
+// scriptError carries the message reported by an injected script while
+// unwrapping to the sentinel error identified by its code.
+type scriptError struct {
+	err error
+	msg string
+}
+
+func (e *scriptError) Error() string { return e.msg }
+func (e *scriptError) Unwrap() error { return e.err }
+
+// resultError converts an { error, code } object returned by an injected
+// script into a Go error. It returns nil if result is not an error object.
+func resultError(result any) error {
+	m, ok := result.(map[string]any)
+	if !ok {
+		return nil
+	}
+	errMsg, ok := m["error"].(string)
+	if !ok {
+		return nil
+	}
+	code, _ := m["code"].(string)
+	if sentinel, ok := scriptErrorCodes[code]; ok {
+		return &scriptError{err: sentinel, msg: errMsg}
+	}
+	return fmt.Errorf("%s", errMsg)
+}
+
+// responseError converts an error response from the extension into a Go
+// error, unwrapping to a sentinel when the extension reported a known code.
+func responseError(e *mcp.Error) error {
+	data, _ := e.Data.(map[string]any)
+	code, _ := data["code"].(string)
+	if sentinel, ok := scriptErrorCodes[code]; ok {
+		return &scriptError{err: sentinel, msg: e.Message}
+	}
+	return e
+}
@@ -0,0 +1,147 @@
+// Package browser implements iframe inspection and scripting.
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// ListIframes returns all iframes in the top-level document of a tab.
+func (c *Controller) ListIframes(ctx context.Context, tabID int) ([]mcp.IframeInfo, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			%s
+			return Array.from(document.querySelectorAll('iframe')).map(el => ({
+				selector: cssPath(el),
+				src: el.src,
+				id: el.id,
+				name: el.name
+			}));
+		})()
+	`, cssPathJS)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := json.Marshal(result)
+	var frames []mcp.IframeInfo
+	if err := json.Unmarshal(data, &frames); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal iframes: %w", err)
+	}
+	return frames, nil
+}
+
+// GetIframeContent extracts the content of an iframe's document. Returns
+// ErrCrossOrigin if the same-origin policy prevents access to the iframe.
+func (c *Controller) GetIframeContent(ctx context.Context, tabID int, iframeSelector string) (*mcp.PageContent, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			if (el.tagName !== 'IFRAME' && el.tagName !== 'FRAME') {
+				return { error: 'Element is not an iframe: ' + el.tagName, code: 'wrong_type' };
+			}
+			let doc = null;
+			try {
+				doc = el.contentDocument;
+			} catch (e) {}
+			if (!doc) {
+				return { error: 'Cannot access cross-origin iframe: ' + el.src, code: 'cross_origin' };
+			}
+			return {
+				title: doc.title,
+				url: doc.location.href,
+				text: doc.body?.innerText || '',
+				html: doc.documentElement.outerHTML,
+				links: Array.from(doc.querySelectorAll('a')).map(a => ({
+					text: a.innerText,
+					href: a.href
+				})).slice(0, 100)
+			};
+		})()
+	`, querySelectorJS(iframeSelector))
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	content := &mcp.PageContent{}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal iframe content: %w", err)
+	}
+	return content, nil
+}
+
+// ExecuteScriptInFrame runs JavaScript inside the document of the iframe
+// matched by frameSelector. Returns ErrCrossOrigin for cross-origin iframes.
+func (c *Controller) ExecuteScriptInFrame(ctx context.Context, tabID int, frameSelector string, script string) (any, error) {
+	locate := fmt.Sprintf(`
+		(() => {
+			const el = %s;
+			if (!el) return { error: 'Element not found', code: 'not_found' };
+			if (el.tagName !== 'IFRAME' && el.tagName !== 'FRAME') {
+				return { error: 'Element is not an iframe: ' + el.tagName, code: 'wrong_type' };
+			}
+			let url = null;
+			try {
+				url = el.contentWindow.location.href;
+			} catch (e) {}
+			if (!url) {
+				return { error: 'Cannot script cross-origin iframe: ' + el.src, code: 'cross_origin' };
+			}
+			return { url };
+		})()
+	`, querySelectorJS(frameSelector))
+
+	result, err := c.ExecuteScript(ctx, tabID, locate)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+	m, _ := result.(map[string]any)
+	frameURL, _ := m["url"].(string)
+
+	resp, err := c.send(ctx, "browser.webNavigation.getAllFrames", map[string]any{
+		"tabId": tabID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	var frames []struct {
+		FrameID       int    `json:"frameId"`
+		ParentFrameID int    `json:"parentFrameId"`
+		URL           string `json:"url"`
+	}
+	if err := json.Unmarshal(resp.Result, &frames); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal frames: %w", err)
+	}
+
+	// Frames are matched by URL among the children of the top-level
+	// document; with duplicate URLs the first frame reported wins.
+	for _, f := range frames {
+		if f.ParentFrameID == 0 && f.FrameID != 0 && f.URL == frameURL {
+			return c.runScript(ctx, map[string]any{
+				"tabId":   tabID,
+				"frameId": f.FrameID,
+				"script":  script,
+			})
+		}
+	}
+	return nil, fmt.Errorf("no frame found for iframe %s (%s)", frameSelector, frameURL)
+}
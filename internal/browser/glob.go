@@ -0,0 +1,69 @@
+// Package browser implements glob matching of URLs.
+package browser
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// compileURLGlob compiles a glob pattern matched against whole URLs. Unlike
+// path.Match, where * stops at /, * matches any run of characters, so
+// https://example.com/* matches https://example.com/a/b. ? matches a single
+// character, [...] a character class ([!...] or [^...] to negate) and \
+// escapes the next character. Malformed patterns return path.ErrBadPattern.
+func compileURLGlob(pattern string) (*regexp.Regexp, error) {
+	runes := []rune(pattern)
+	var b strings.Builder
+	b.WriteString(`^`)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '*':
+			b.WriteString(`.*`)
+		case '?':
+			b.WriteString(`.`)
+		case '\\':
+			if i+1 == len(runes) {
+				return nil, path.ErrBadPattern
+			}
+			i++
+			b.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case '[':
+			end := slices.Index(runes[i+1:], ']')
+			if end < 0 {
+				return nil, path.ErrBadPattern
+			}
+			class := string(runes[i+1 : i+1+end])
+			i += end + 1
+			negate := strings.HasPrefix(class, "!") || strings.HasPrefix(class, "^")
+			if negate {
+				class = class[1:]
+			}
+			if class == "" {
+				return nil, path.ErrBadPattern
+			}
+			b.WriteString(`[`)
+			if negate {
+				b.WriteString(`^`)
+			}
+			for _, r := range class {
+				if r == '-' {
+					b.WriteRune(r)
+				} else {
+					b.WriteString(regexp.QuoteMeta(string(r)))
+				}
+			}
+			b.WriteString(`]`)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(runes[i])))
+		}
+	}
+	b.WriteString(`$`)
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		// Such as a class with a reversed range
+		return nil, path.ErrBadPattern
+	}
+	return re, nil
+}
@@ -0,0 +1,45 @@
+package browser
+
+import (
+	"errors"
+	"path"
+	"testing"
+)
+
+func TestCompileURLGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		url     string
+		want    bool
+	}{
+		{"https://example.com/*", "https://example.com/a/b", true},
+		{"https://example.com/*", "https://example.com/", true},
+		{"https://example.com/*", "https://example.org/a", false},
+		{"https://github.com/*/issues", "https://github.com/org/repo/issues", true},
+		{"https://github.com/*/issues", "https://github.com/org/repo/issues/1", false},
+		{"*://example.com/page?", "http://example.com/page2", true},
+		{"https://example.com/page?id=1", "https://example.com/pageXid=1", true},
+		{`https://example.com/page\?id=1`, "https://example.com/pageXid=1", false},
+		{`https://example.com/page\?id=1`, "https://example.com/page?id=1", true},
+		{"https://example.com/v[0-9]/*", "https://example.com/v2/docs", true},
+		{"https://example.com/v[!0-9]/*", "https://example.com/v2/docs", false},
+		{"https://example.com/a.b", "https://example.com/aXb", false},
+		{"https://example.com/ü*", "https://example.com/über", true},
+	}
+	for _, tt := range tests {
+		glob, err := compileURLGlob(tt.pattern)
+		if err != nil {
+			t.Errorf("compileURLGlob(%q): %v", tt.pattern, err)
+			continue
+		}
+		if got := glob.MatchString(tt.url); got != tt.want {
+			t.Errorf("%q matches %q = %v, want %v", tt.pattern, tt.url, got, tt.want)
+		}
+	}
+
+	for _, pattern := range []string{"https://example.com/[", `https://example.com/\`, "https://example.com/[]", "https://example.com/[z-a]"} {
+		if _, err := compileURLGlob(pattern); !errors.Is(err, path.ErrBadPattern) {
+			t.Errorf("compileURLGlob(%q) = %v, want ErrBadPattern", pattern, err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Package browser implements retries for transient extension errors.
+package browser
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// WithRetry retries extension requests that could not be sent because the
+// connection was lost, up to maxAttempts times in total, waiting backoff
+// before the first retry and doubling it after each one. Requests that
+// reached the extension are never retried, since operations such as clicks
+// or closing a tab must not run twice.
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(c *Controller) {
+		if maxAttempts > 1 {
+			c.retryAttempts = maxAttempts
+			c.retryBackoff = backoff
+		}
+	}
+}
+
+// WithLogger sets the logger used for retry attempts.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Controller) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// send sends a request to the extension, retrying as configured with
+// WithRetry. Waiting between attempts stops when ctx is done.
+func (c *Controller) send(ctx context.Context, method string, params any) (*mcp.Message, error) {
+	delay := c.retryBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sender.SendRequest(method, params)
+		if !errors.Is(err, mcp.ErrNotSent) || attempt >= c.retryAttempts {
+			return resp, err
+		}
+		c.logger.Warn("retrying extension request",
+			"method", method,
+			slog.Group("retry",
+				"attempt", attempt,
+				"error", err,
+				"next_delay", delay,
+			),
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
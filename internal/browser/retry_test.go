@@ -0,0 +1,86 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// flakySender fails its first failures calls with err, then succeeds.
+type flakySender struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *flakySender) SendRequest(method string, params any) (*mcp.Message, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, f.err
+	}
+	return &mcp.Message{Result: json.RawMessage(`[]`)}, nil
+}
+
+func newRetryController(sender RequestSender) *Controller {
+	return NewController(sender,
+		WithRetry(3, time.Millisecond),
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	)
+}
+
+func TestRetrySucceedsAfterTransientFailures(t *testing.T) {
+	sender := &flakySender{failures: 2, err: fmt.Errorf("not connected: %w", mcp.ErrNotSent)}
+	c := newRetryController(sender)
+
+	if _, err := c.ListTabs(context.Background()); err != nil {
+		t.Fatalf("ListTabs: %v", err)
+	}
+	if sender.calls != 3 {
+		t.Errorf("calls = %d, want 3", sender.calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	sender := &flakySender{failures: 5, err: fmt.Errorf("not connected: %w", mcp.ErrNotSent)}
+	c := newRetryController(sender)
+
+	if _, err := c.ListTabs(context.Background()); !errors.Is(err, mcp.ErrNotSent) {
+		t.Fatalf("err = %v, want ErrNotSent", err)
+	}
+	if sender.calls != 3 {
+		t.Errorf("calls = %d, want 3", sender.calls)
+	}
+}
+
+func TestRetrySkipsSentRequests(t *testing.T) {
+	sender := &flakySender{failures: 1, err: errors.New("request timeout")}
+	c := newRetryController(sender)
+
+	if _, err := c.ListTabs(context.Background()); err == nil {
+		t.Fatal("expected the timeout error")
+	}
+	if sender.calls != 1 {
+		t.Errorf("calls = %d, want 1", sender.calls)
+	}
+}
+
+func TestRetryStopsOnContextDone(t *testing.T) {
+	sender := &flakySender{failures: 5, err: fmt.Errorf("not connected: %w", mcp.ErrNotSent)}
+	c := NewController(sender,
+		WithRetry(3, time.Hour),
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.ListTabs(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
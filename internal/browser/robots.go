@@ -0,0 +1,159 @@
+// Package browser implements robots.txt retrieval and matching.
+package browser
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// GetRobotsTxt returns the robots.txt of the origin of the tab's page.
+func (c *Controller) GetRobotsTxt(ctx context.Context, tabID int) (string, error) {
+	status, text, err := c.fetchRobotsTxt(ctx, tabID)
+	if err != nil {
+		return "", err
+	}
+	if status < 200 || status > 299 {
+		return "", fmt.Errorf("robots.txt returned HTTP %d", status)
+	}
+	return text, nil
+}
+
+// CheckRobotsAllowed reports whether robots.txt of the tab's origin allows
+// userAgent to crawl path, following RFC 9309: the group naming the most
+// specific matching user agent applies, falling back to "*", and within it
+// the longest matching rule wins, with allow winning ties. A robots.txt
+// that is missing (HTTP 4xx) allows everything.
+func (c *Controller) CheckRobotsAllowed(ctx context.Context, tabID int, path, userAgent string) (bool, error) {
+	status, text, err := c.fetchRobotsTxt(ctx, tabID)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case status >= 400 && status <= 499:
+		return true, nil
+	case status < 200 || status > 299:
+		return false, fmt.Errorf("robots.txt returned HTTP %d", status)
+	}
+	if path == "" {
+		path = "/"
+	}
+	return robotsAllowed(text, path, userAgent)
+}
+
+// fetchRobotsTxt fetches /robots.txt from the page's origin.
+func (c *Controller) fetchRobotsTxt(ctx context.Context, tabID int) (int, string, error) {
+	script := `
+		fetch(new URL('/robots.txt', location.origin), { credentials: 'omit' })
+			.then(async response => ({ status: response.status, text: await response.text() }))
+			.catch(e => ({ error: 'Failed to fetch robots.txt: ' + e.message }))
+	`
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return 0, "", err
+	}
+	if err := resultError(result); err != nil {
+		return 0, "", err
+	}
+	m, _ := result.(map[string]any)
+	status, _ := m["status"].(float64)
+	text, _ := m["text"].(string)
+	return int(status), text, nil
+}
+
+// robotsRule is an allow or disallow line of a robots.txt group.
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// maxRobotsLine is the longest robots.txt line robotsAllowed accepts, the
+// 500 KiB RFC 9309 requires crawlers to parse.
+const maxRobotsLine = 500 << 10
+
+// robotsAllowed evaluates robots.txt content for path and userAgent.
+func robotsAllowed(robots, path, userAgent string) (bool, error) {
+	groups := make(map[string][]robotsRule)
+	var agents []string
+	inRules := false
+
+	scanner := bufio.NewScanner(strings.NewReader(robots))
+	scanner.Buffer(make([]byte, 0, 64<<10), maxRobotsLine)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// A user-agent line after rules starts a new group
+			if inRules {
+				agents, inRules = nil, false
+			}
+			agent := strings.ToLower(value)
+			agents = append(agents, agent)
+			if _, ok := groups[agent]; !ok {
+				groups[agent] = nil
+			}
+		case "allow", "disallow":
+			inRules = true
+			// An empty disallow allows everything and adds no rule
+			if value == "" {
+				continue
+			}
+			for _, agent := range agents {
+				groups[agent] = append(groups[agent], robotsRule{allow: key == "allow", pattern: value})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to parse robots.txt: %w", err)
+	}
+
+	ua := strings.ToLower(userAgent)
+	best := ""
+	for agent := range groups {
+		if agent != "*" && strings.Contains(ua, agent) && len(agent) > len(best) {
+			best = agent
+		}
+	}
+	if best == "" {
+		best = "*"
+	}
+
+	allowed, matchLen := true, -1
+	for _, rule := range groups[best] {
+		if !robotsPatternMatch(rule.pattern, path) {
+			continue
+		}
+		if len(rule.pattern) > matchLen || (len(rule.pattern) == matchLen && rule.allow) {
+			allowed, matchLen = rule.allow, len(rule.pattern)
+		}
+	}
+	return allowed, nil
+}
+
+// robotsPatternMatch matches a robots.txt path pattern, where * matches any
+// sequence of characters and a trailing $ anchors the end of the path.
+func robotsPatternMatch(pattern, path string) bool {
+	if !strings.ContainsAny(pattern, "*$") {
+		return strings.HasPrefix(path, pattern)
+	}
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(path)
+}
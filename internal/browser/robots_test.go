@@ -0,0 +1,88 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRobotsAllowed(t *testing.T) {
+	const robots = `
+# Example robots.txt
+User-agent: *
+Disallow: /private/
+Allow: /private/public
+Disallow: /*.pdf$
+
+User-agent: Googlebot
+User-agent: Bingbot
+Disallow: /no-bots
+Allow: /
+
+User-agent: Googlebot-Image
+Disallow:
+`
+	tests := []struct {
+		path, userAgent string
+		want            bool
+	}{
+		{"/", "Mozilla/5.0", true},
+		{"/private/secret", "Mozilla/5.0", false},
+		{"/private/public/page", "Mozilla/5.0", true},
+		{"/files/report.pdf", "Mozilla/5.0", false},
+		{"/files/report.pdf?download=1", "Mozilla/5.0", true},
+		{"/private/secret", "Googlebot/2.1", true},
+		{"/no-bots", "Googlebot/2.1", false},
+		{"/no-bots", "bingbot/2.0", false},
+		{"/no-bots", "Googlebot-Image/1.0", true},
+		{"/private/secret", "Googlebot-Image/1.0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.userAgent+tt.path, func(t *testing.T) {
+			got, err := robotsAllowed(robots, tt.path, tt.userAgent)
+			if err != nil {
+				t.Fatalf("robotsAllowed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("robotsAllowed(%q, %q) = %t, want %t", tt.path, tt.userAgent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRobotsAllowedLongLines(t *testing.T) {
+	long := "Disallow: /" + strings.Repeat("a", 100<<10)
+	if _, err := robotsAllowed("User-agent: *\n"+long+"\nDisallow: /x\n", "/x", "bot"); err != nil {
+		t.Errorf("100 KiB line: %v", err)
+	}
+	tooLong := "Disallow: /" + strings.Repeat("a", maxRobotsLine)
+	if _, err := robotsAllowed("User-agent: *\n"+tooLong+"\n", "/", "bot"); err == nil {
+		t.Error("line over the limit: got no error")
+	}
+}
+
+func TestRobotsPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"/", "/anything", true},
+		{"/fish", "/fish.html", true},
+		{"/fish", "/Fish", false},
+		{"/fish/", "/fish", false},
+		{"/*.php", "/index.php", true},
+		{"/*.php", "/folder/file.php?x=1", true},
+		{"/*.php$", "/index.php", true},
+		{"/*.php$", "/index.php?x=1", false},
+		{"/fish*", "/fishheads", true},
+		{"/a*b*c", "/a-b-c", true},
+		{"/a*b*c", "/a-c", false},
+		{"/$", "/", true},
+		{"/$", "/page", false},
+		{"/a.b", "/axb", false},
+	}
+	for _, tt := range tests {
+		if got := robotsPatternMatch(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("robotsPatternMatch(%q, %q) = %t, want %t", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
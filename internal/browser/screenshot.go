@@ -0,0 +1,241 @@
+// Package browser implements element screenshots.
+package browser
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// ScreenshotElement captures the element matched by selector as a data URL.
+// The element is scrolled into view, the visible tab is captured and the
+// image is cropped to the element's bounding box, clipped to the viewport.
+// Returns ErrNotFound if the selector matches nothing.
+func (c *Controller) ScreenshotElement(ctx context.Context, tabID int, selector string, opts mcp.ScreenshotOptions) (string, error) {
+	if err := c.ScrollToElement(ctx, tabID, selector, "instant"); err != nil {
+		return "", err
+	}
+	rect, err := c.GetBoundingRect(ctx, tabID, selector, false)
+	if err != nil {
+		return "", err
+	}
+	result, err := c.ExecuteScript(ctx, tabID, "window.innerWidth")
+	if err != nil {
+		return "", err
+	}
+	viewportWidth, _ := result.(float64)
+
+	dataURL, err := c.ScreenshotTab(ctx, tabID)
+	if err != nil {
+		return "", err
+	}
+	img, err := decodeScreenshot(dataURL)
+	if err != nil {
+		return "", err
+	}
+
+	// The capture is in device pixels while the rect is in CSS pixels
+	scale := 1.0
+	if viewportWidth > 0 {
+		scale = float64(img.Bounds().Dx()) / viewportWidth
+	}
+	crop := image.Rect(
+		int(rect.Left*scale), int(rect.Top*scale),
+		int(rect.Right*scale+0.5), int(rect.Bottom*scale+0.5),
+	).Add(img.Bounds().Min).Intersect(img.Bounds())
+	if crop.Empty() {
+		return "", fmt.Errorf("element %s is not visible in the viewport", selector)
+	}
+	cropped := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}).SubImage(crop)
+
+	var buf bytes.Buffer
+	mime := "image/png"
+	if opts.Format == "jpeg" {
+		mime = "image/jpeg"
+		quality := opts.Quality
+		if quality <= 0 || quality > 100 {
+			quality = jpeg.DefaultQuality
+		}
+		err = jpeg.Encode(&buf, cropped, &jpeg.Options{Quality: quality})
+	} else {
+		err = png.Encode(&buf, cropped)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to encode screenshot: %w", err)
+	}
+	return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// maxImagePixels caps the size of images decodeScreenshot accepts, well
+// above any real viewport capture, so that a crafted header cannot make the
+// decoder allocate gigabytes.
+const maxImagePixels = 1 << 25
+
+// decodeScreenshot decodes a PNG or JPEG screenshot, given as a data URL
+// or plain base64, into an image. Returns ErrPayloadTooLarge for images
+// over maxImagePixels.
+func decodeScreenshot(dataURL string) (image.Image, error) {
+	encoded := dataURL
+	if strings.HasPrefix(dataURL, "data:") {
+		_, encoded, _ = strings.Cut(dataURL, ",")
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, fmt.Errorf("image is %dx%d, limit is %d pixels: %w", cfg.Width, cfg.Height, maxImagePixels, ErrPayloadTooLarge)
+	}
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+	return img, nil
+}
+
+// captureCacheTTL is how long GetColorAt reuses a tab capture.
+const captureCacheTTL = 500 * time.Millisecond
+
+// cachedCapture is a decoded tab capture kept for repeated pixel sampling.
+type cachedCapture struct {
+	tabID int
+	img   image.Image
+	scale float64 // device pixels per CSS pixel
+	at    time.Time
+}
+
+// GetColorAt returns the color of the pixel at viewport coordinates (x, y),
+// in CSS pixels, as rendered in a capture of the visible tab. Captures are
+// reused for 500ms so that sampling several points costs one screenshot.
+// Returns ErrOutOfBounds if the point lies outside the capture.
+func (c *Controller) GetColorAt(ctx context.Context, tabID int, x, y int) (*mcp.Color, error) {
+	capture, err := c.captureForSampling(ctx, tabID)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := capture.img.Bounds()
+	pt := image.Pt(int(float64(x)*capture.scale), int(float64(y)*capture.scale)).Add(bounds.Min)
+	if x < 0 || y < 0 || !pt.In(bounds) {
+		return nil, fmt.Errorf("point (%d, %d) outside %dx%d viewport: %w",
+			x, y, int(float64(bounds.Dx())/capture.scale), int(float64(bounds.Dy())/capture.scale), ErrOutOfBounds)
+	}
+
+	px := color.NRGBAModel.Convert(capture.img.At(pt.X, pt.Y)).(color.NRGBA)
+	return &mcp.Color{
+		R:   px.R,
+		G:   px.G,
+		B:   px.B,
+		A:   px.A,
+		Hex: fmt.Sprintf("#%02x%02x%02x", px.R, px.G, px.B),
+	}, nil
+}
+
+// captureForSampling returns a recent capture of the tab, taking a new one
+// if the cached capture is older than captureCacheTTL or of another tab.
+func (c *Controller) captureForSampling(ctx context.Context, tabID int) (*cachedCapture, error) {
+	c.captureMu.Lock()
+	defer c.captureMu.Unlock()
+
+	if cached := c.capture; cached != nil && cached.tabID == tabID && time.Since(cached.at) < captureCacheTTL {
+		return cached, nil
+	}
+
+	result, err := c.ExecuteScript(ctx, tabID, "window.innerWidth")
+	if err != nil {
+		return nil, err
+	}
+	viewportWidth, _ := result.(float64)
+
+	dataURL, err := c.ScreenshotTab(ctx, tabID)
+	if err != nil {
+		return nil, err
+	}
+	img, err := decodeScreenshot(dataURL)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := 1.0
+	if viewportWidth > 0 {
+		scale = float64(img.Bounds().Dx()) / viewportWidth
+	}
+	c.capture = &cachedCapture{tabID: tabID, img: img, scale: scale, at: time.Now()}
+	return c.capture, nil
+}
+
+// DiffScreenshots compares a capture of the visible tab against a baseline
+// PNG or JPEG screenshot, given as a data URL or plain base64. Pixels
+// differ when any RGBA channel differs; where the image sizes differ, the
+// non-overlapping area counts as changed. The diff image is the current
+// capture with differing pixels painted red. Changed is set when the
+// fraction of differing pixels exceeds threshold, e.g. 0.01 for 1%.
+func (c *Controller) DiffScreenshots(ctx context.Context, tabID int, baselineBase64 string, threshold float64) (*mcp.ScreenshotDiff, error) {
+	baseline, err := decodeScreenshot(baselineBase64)
+	if err != nil {
+		return nil, fmt.Errorf("baseline: %w", err)
+	}
+	dataURL, err := c.ScreenshotTab(ctx, tabID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := decodeScreenshot(dataURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cb, bb := current.Bounds(), baseline.Bounds()
+	width, height := max(cb.Dx(), bb.Dx()), max(cb.Dy(), bb.Dy())
+	diff := image.NewNRGBA(image.Rect(0, 0, width, height))
+	red := color.NRGBA{R: 255, A: 255}
+
+	changed := 0
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cp := image.Pt(x, y).Add(cb.Min)
+			bp := image.Pt(x, y).Add(bb.Min)
+			if !cp.In(cb) || !bp.In(bb) {
+				diff.SetNRGBA(x, y, red)
+				changed++
+				continue
+			}
+			now := color.NRGBAModel.Convert(current.At(cp.X, cp.Y)).(color.NRGBA)
+			was := color.NRGBAModel.Convert(baseline.At(bp.X, bp.Y)).(color.NRGBA)
+			if now != was {
+				diff.SetNRGBA(x, y, red)
+				changed++
+				continue
+			}
+			diff.SetNRGBA(x, y, now)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, diff); err != nil {
+		return nil, fmt.Errorf("failed to encode diff image: %w", err)
+	}
+	fraction := 0.0
+	if width*height > 0 {
+		fraction = float64(changed) / float64(width*height)
+	}
+	return &mcp.ScreenshotDiff{
+		DifferencePercent: fraction * 100,
+		DiffImageBase64:   base64.StdEncoding.EncodeToString(buf.Bytes()),
+		Changed:           fraction > threshold,
+	}, nil
+}
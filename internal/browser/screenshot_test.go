@@ -0,0 +1,97 @@
+package browser
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// captureSender answers captureVisibleTab with a fixed data URL and every
+// other request with a null result.
+type captureSender struct {
+	dataURL string
+}
+
+func (s captureSender) SendRequest(method string, params any) (*mcp.Message, error) {
+	if method == "browser.tabs.captureVisibleTab" {
+		data, _ := json.Marshal(s.dataURL)
+		return &mcp.Message{Result: data}, nil
+	}
+	return &mcp.Message{Result: json.RawMessage("null")}, nil
+}
+
+func encodePNG(t *testing.T, w, h int, at func(x, y int) color.Color) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, at(x, y))
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func white(x, y int) color.Color { return color.White }
+
+func TestDiffScreenshots(t *testing.T) {
+	// The current capture differs from the white baseline in its first
+	// column by an almost invisible amount, which must still count.
+	current := encodePNG(t, 10, 10, func(x, y int) color.Color {
+		if x == 0 {
+			return color.NRGBA{R: 0xf5, G: 0xf5, B: 0xf5, A: 0xff}
+		}
+		return color.White
+	})
+	baseline := base64.StdEncoding.EncodeToString(encodePNG(t, 10, 10, white))
+	c := NewController(captureSender{"data:image/png;base64," + base64.StdEncoding.EncodeToString(current)})
+
+	tests := []struct {
+		threshold float64
+		changed   bool
+	}{
+		{0, true},
+		{0.05, true},
+		{0.2, false},
+	}
+	for _, tt := range tests {
+		diff, err := c.DiffScreenshots(context.Background(), 1, baseline, tt.threshold)
+		if err != nil {
+			t.Fatalf("threshold %g: %v", tt.threshold, err)
+		}
+		if diff.DifferencePercent != 10 {
+			t.Errorf("threshold %g: differencePercent = %g, want 10", tt.threshold, diff.DifferencePercent)
+		}
+		if diff.Changed != tt.changed {
+			t.Errorf("threshold %g: changed = %v, want %v", tt.threshold, diff.Changed, tt.changed)
+		}
+	}
+}
+
+func TestDiffScreenshotsRejectsHugeBaseline(t *testing.T) {
+	// Rewrite the IHDR chunk of a 1x1 PNG to claim 100000x100000 pixels
+	data := encodePNG(t, 1, 1, white)
+	binary.BigEndian.PutUint32(data[16:], 100000)
+	binary.BigEndian.PutUint32(data[20:], 100000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	current := base64.StdEncoding.EncodeToString(encodePNG(t, 10, 10, white))
+	c := NewController(captureSender{"data:image/png;base64," + current})
+	_, err := c.DiffScreenshots(context.Background(), 1, base64.StdEncoding.EncodeToString(data), 0)
+	if !errors.Is(err, ErrPayloadTooLarge) {
+		t.Fatalf("err = %v, want ErrPayloadTooLarge", err)
+	}
+}
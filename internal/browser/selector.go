@@ -0,0 +1,106 @@
+// Package browser translates extended selectors into DOM queries.
+package browser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Text selectors extend CSS with matching by visible text:
+//
+//	text="Submit"   element whose trimmed text is exactly "Submit"
+//	text*="Sub"     innermost element whose text contains "Sub"
+const (
+	textExactPrefix     = "text="
+	textSubstringPrefix = "text*="
+)
+
+// parseTextSelector reports whether selector is a text selector and, if so,
+// returns the text to match and whether the match must be exact.
+func parseTextSelector(selector string) (text string, exact bool, ok bool) {
+	var quoted string
+	switch {
+	case strings.HasPrefix(selector, textSubstringPrefix):
+		quoted = strings.TrimPrefix(selector, textSubstringPrefix)
+	case strings.HasPrefix(selector, textExactPrefix):
+		quoted, exact = strings.TrimPrefix(selector, textExactPrefix), true
+	default:
+		return "", false, false
+	}
+
+	text, err := strconv.Unquote(quoted)
+	if err != nil {
+		return "", false, false
+	}
+	return text, exact, true
+}
+
+// cssPathJS defines a JavaScript function cssPath(el) that builds a short
+// CSS selector for an element, anchored at the nearest ancestor with an ID.
+const cssPathJS = `
+	const cssPath = (node) => {
+		const parts = [];
+		for (let el = node; el && el.nodeType === Node.ELEMENT_NODE && parts.length < 5; el = el.parentElement) {
+			if (el.id) { parts.unshift('#' + CSS.escape(el.id)); break; }
+			const siblings = el.parentElement ? Array.from(el.parentElement.children).filter(s => s.tagName === el.tagName) : [];
+			const tag = el.tagName.toLowerCase();
+			parts.unshift(siblings.length > 1 ? tag + ':nth-of-type(' + (siblings.indexOf(el) + 1) + ')' : tag);
+		}
+		return parts.join(' > ');
+	};
+`
+
+// uniqueSelectorJS defines a JavaScript function uniqueSelector(el) that
+// builds a CSS selector matching el and nothing else. Steps are qualified
+// with :nth-of-type all the way up, stopping at the first ancestor whose
+// ID is unique or as soon as the path matches a single element.
+const uniqueSelectorJS = `
+	const uniqueSelector = (node) => {
+		const unique = (sel) => document.querySelectorAll(sel).length === 1;
+		const parts = [];
+		for (let el = node; el && el.nodeType === Node.ELEMENT_NODE; el = el.parentElement) {
+			if (el.id && unique('#' + CSS.escape(el.id))) {
+				parts.unshift('#' + CSS.escape(el.id));
+				break;
+			}
+			const tag = el.tagName.toLowerCase();
+			if (!el.parentElement) {
+				parts.unshift(tag);
+				break;
+			}
+			const index = Array.from(el.parentElement.children).filter(s => s.tagName === el.tagName).indexOf(el) + 1;
+			parts.unshift(tag + ':nth-of-type(' + index + ')');
+			if (unique(parts.join(' > '))) break;
+		}
+		return parts.join(' > ');
+	};
+`
+
+// querySelectorAllJS returns a JavaScript expression that evaluates to an
+// array of all elements matching selector.
+func querySelectorAllJS(selector string) string {
+	text, exact, ok := parseTextSelector(selector)
+	if !ok {
+		return fmt.Sprintf("Array.from(document.querySelectorAll(%q))", selector)
+	}
+
+	candidates := "Array.from(document.querySelectorAll('body *')).filter(el => !['SCRIPT', 'STYLE', 'NOSCRIPT'].includes(el.tagName))"
+	match := fmt.Sprintf("el.textContent.includes(%q)", text)
+	if exact {
+		match = fmt.Sprintf("el.textContent.trim() === %q", text)
+	}
+	// Ancestors of a match match too, every one of them for substrings and
+	// wrappers with no other text for exact matches, so keep only the
+	// innermost elements.
+	return fmt.Sprintf(`((matches) => matches.filter(el => !matches.some(o => o !== el && el.contains(o))))(%s.filter(el => %s))`, candidates, match)
+}
+
+// querySelectorJS returns a JavaScript expression that evaluates to the
+// first element matching selector, or null.
+func querySelectorJS(selector string) string {
+	if _, _, ok := parseTextSelector(selector); !ok {
+		return fmt.Sprintf("document.querySelector(%q)", selector)
+	}
+	return fmt.Sprintf("(%s[0] || null)", querySelectorAllJS(selector))
+}
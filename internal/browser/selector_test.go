@@ -0,0 +1,72 @@
+package browser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+func TestQuerySelectorAllJS(t *testing.T) {
+	const innermost = "matches.filter(el => !matches.some(o => o !== el && el.contains(o)))"
+	tests := []struct {
+		selector string
+		want     []string
+		notWant  []string
+	}{
+		{`button.primary`, []string{`document.querySelectorAll("button.primary")`}, []string{innermost}},
+		{`text="Buy now"`, []string{innermost, `el.textContent.trim() === "Buy now"`}, nil},
+		{`text*="Buy"`, []string{innermost, `el.textContent.includes("Buy")`}, nil},
+		{`text="unterminated`, []string{`document.querySelectorAll("text=\"unterminated")`}, []string{innermost}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			js := querySelectorAllJS(tt.selector)
+			for _, want := range tt.want {
+				if !strings.Contains(js, want) {
+					t.Errorf("missing %s in:\n%s", want, js)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(js, notWant) {
+					t.Errorf("unexpected %s in:\n%s", notWant, js)
+				}
+			}
+		})
+	}
+}
+
+func TestGetFormFieldsUsesUniqueSelectors(t *testing.T) {
+	var script string
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		script = params.(map[string]any)["script"].(string)
+		return scriptResult(t, []any{map[string]any{"name": "q", "type": "text", "selector": "#search > input:nth-of-type(1)"}}), nil
+	}))
+
+	fields, err := c.GetFormFields(context.Background(), 1, "#search")
+	if err != nil {
+		t.Fatalf("GetFormFields: %v", err)
+	}
+	if len(fields) != 1 || fields[0].Selector != "#search > input:nth-of-type(1)" {
+		t.Errorf("fields = %+v", fields)
+	}
+	for _, want := range []string{"selector: uniqueSelector(el)", "document.querySelectorAll(sel).length === 1"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script does not contain %s:\n%s", want, script)
+		}
+	}
+}
+
+func TestInvalidSelectorErrors(t *testing.T) {
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		return scriptResult(t, map[string]any{"error": "Invalid selector: 'div[' is not a valid selector."}), nil
+	}))
+
+	if n, err := c.CountElements(context.Background(), 1, "div["); err == nil {
+		t.Errorf("CountElements = %d, want an error", n)
+	}
+	if ok, err := c.ElementExists(context.Background(), 1, "div["); err == nil {
+		t.Errorf("ElementExists = %t, want an error", ok)
+	}
+}
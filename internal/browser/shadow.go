@@ -0,0 +1,103 @@
+// Package browser implements shadow DOM inspection.
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// shadowRootJS returns a script prefix that sets root to the shadow root of
+// the element matched by hostSelector, or returns an error object. Closed
+// shadow roots are reachable where the extension API chrome.dom is exposed
+// to injected scripts.
+func shadowRootJS(hostSelector string) string {
+	return fmt.Sprintf(`
+			const host = %s;
+			if (!host) return { error: 'Element not found', code: 'not_found' };
+			const root = globalThis.chrome?.dom?.openOrClosedShadowRoot?.(host) || host.shadowRoot;
+			if (!root) return { error: 'Element has no shadow root: ' + host.tagName, code: 'wrong_type' };
+	`, querySelectorJS(hostSelector))
+}
+
+// GetShadowRoot extracts the content of the shadow root attached to the
+// element matched by hostSelector. Title and URL are those of the page.
+// Returns ErrNotFound if nothing matches and ErrWrongType if the element
+// hosts no shadow root.
+func (c *Controller) GetShadowRoot(ctx context.Context, tabID int, hostSelector string) (*mcp.PageContent, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			%s
+			return {
+				title: document.title,
+				url: location.href,
+				text: root.textContent.trim(),
+				html: root.innerHTML,
+				links: Array.from(root.querySelectorAll('a')).map(a => ({
+					text: a.innerText,
+					href: a.href
+				})).slice(0, 100)
+			};
+		})()
+	`, shadowRootJS(hostSelector))
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	content := &mcp.PageContent{}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal shadow root content: %w", err)
+	}
+	return content, nil
+}
+
+// QueryShadowRoot returns the elements matching the CSS innerSelector inside
+// the shadow root of the element matched by hostSelector. Selectors in the
+// results are relative to the shadow root.
+func (c *Controller) QueryShadowRoot(ctx context.Context, tabID int, hostSelector, innerSelector string) ([]mcp.ElementInfo, error) {
+	script := fmt.Sprintf(`
+		(() => {
+			%s
+			%s
+			let elements;
+			try {
+				elements = Array.from(root.querySelectorAll(%q));
+			} catch (e) {
+				return { error: e.message };
+			}
+			return {
+				elements: elements.map(el => ({
+					tagName: el.tagName,
+					text: el.innerText?.slice(0, 200),
+					visible: el.offsetParent !== null,
+					selector: cssPath(el)
+				}))
+			};
+		})()
+	`, shadowRootJS(hostSelector), cssPathJS, innerSelector)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	var found struct {
+		Elements []mcp.ElementInfo `json:"elements"`
+	}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, &found); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal shadow root elements: %w", err)
+	}
+	return found.Elements, nil
+}
@@ -0,0 +1,159 @@
+// Package browser implements XML sitemap retrieval.
+package browser
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// maxSitemapDepth bounds how many levels of sitemap index files are followed.
+const maxSitemapDepth = 3
+
+// Limits on the work GetSitemap does for one call. The entry and size
+// limits are those the sitemap protocol sets for a single file.
+const (
+	maxSitemapFetches = 50
+	maxSitemapEntries = 50000
+	maxSitemapSize    = 50 << 20
+)
+
+// sitemapDocument is either a urlset or a sitemapindex document.
+type sitemapDocument struct {
+	XMLName xml.Name
+	URLs    []struct {
+		Loc        string `xml:"loc"`
+		LastMod    string `xml:"lastmod"`
+		ChangeFreq string `xml:"changefreq"`
+		Priority   string `xml:"priority"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// GetSitemap returns the entries of /sitemap.xml at the origin of the tab's
+// page. Sitemap index files are followed up to three levels deep and
+// gzip-compressed sitemaps are decompressed. Nested sitemaps that cannot
+// be fetched or parsed are logged and skipped. At most 50 sitemaps are
+// fetched and 50000 entries returned; a sitemap over 50 MB uncompressed
+// fails with ErrPayloadTooLarge.
+func (c *Controller) GetSitemap(ctx context.Context, tabID int) ([]mcp.SitemapEntry, error) {
+	entries := []mcp.SitemapEntry{}
+	visited := make(map[string]bool)
+	if err := c.collectSitemap(ctx, tabID, "/sitemap.xml", 0, visited, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// collectSitemap fetches the sitemap at url and appends its entries,
+// recursing into the sitemaps listed by an index.
+func (c *Controller) collectSitemap(ctx context.Context, tabID int, url string, depth int, visited map[string]bool, entries *[]mcp.SitemapEntry) error {
+	visited[url] = true
+	data, err := c.fetchSitemap(ctx, tabID, url)
+	if err != nil {
+		return err
+	}
+
+	var doc sitemapDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse sitemap %s: %w", url, err)
+	}
+
+	for _, u := range doc.URLs {
+		if len(*entries) >= maxSitemapEntries {
+			c.logger.Warn("sitemap entry limit reached, truncating", "sitemap", url, "limit", maxSitemapEntries)
+			return nil
+		}
+		entry := mcp.SitemapEntry{
+			Loc:        strings.TrimSpace(u.Loc),
+			LastMod:    strings.TrimSpace(u.LastMod),
+			ChangeFreq: strings.TrimSpace(u.ChangeFreq),
+		}
+		entry.Priority, _ = strconv.ParseFloat(strings.TrimSpace(u.Priority), 64)
+		*entries = append(*entries, entry)
+	}
+
+	if doc.XMLName.Local != "sitemapindex" {
+		return nil
+	}
+	if depth+1 >= maxSitemapDepth {
+		c.logger.Warn("sitemap index nesting too deep, skipping", "sitemap", url)
+		return nil
+	}
+	for _, s := range doc.Sitemaps {
+		loc := strings.TrimSpace(s.Loc)
+		if loc == "" || visited[loc] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(visited) >= maxSitemapFetches || len(*entries) >= maxSitemapEntries {
+			c.logger.Warn("sitemap limit reached, skipping remaining sitemaps", "sitemap", url)
+			return nil
+		}
+		if err := c.collectSitemap(ctx, tabID, loc, depth+1, visited, entries); err != nil {
+			c.logger.Warn("skipping nested sitemap", "sitemap", loc, "error", err)
+		}
+	}
+	return nil
+}
+
+// fetchSitemap fetches url, resolved against the page's origin, from the
+// page and returns the body, decompressing it if it is gzip data.
+func (c *Controller) fetchSitemap(ctx context.Context, tabID int, url string) ([]byte, error) {
+	script := fmt.Sprintf(`
+		fetch(new URL(%q, location.origin), { credentials: 'omit' })
+			.then(async response => {
+				if (!response.ok) return { error: 'Sitemap returned HTTP ' + response.status };
+				const bytes = new Uint8Array(await response.arrayBuffer());
+				let binary = '';
+				for (let i = 0; i < bytes.length; i += 0x8000) {
+					binary += String.fromCharCode(...bytes.subarray(i, i + 0x8000));
+				}
+				return { data: btoa(binary) };
+			})
+			.catch(e => ({ error: 'Failed to fetch sitemap: ' + e.message }))
+	`, url)
+
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, fmt.Errorf("%s: %w", url, err)
+	}
+	m, _ := result.(map[string]any)
+	encoded, _ := m["data"].(string)
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode sitemap %s: %w", url, err)
+	}
+
+	// Servers may send .xml.gz files as-is rather than with a
+	// Content-Encoding the browser would undo
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress sitemap %s: %w", url, err)
+		}
+		defer zr.Close()
+		if data, err = io.ReadAll(io.LimitReader(zr, maxSitemapSize+1)); err != nil {
+			return nil, fmt.Errorf("failed to decompress sitemap %s: %w", url, err)
+		}
+	}
+	if len(data) > maxSitemapSize {
+		return nil, fmt.Errorf("sitemap %s exceeds %d bytes: %w", url, maxSitemapSize, ErrPayloadTooLarge)
+	}
+	return data, nil
+}
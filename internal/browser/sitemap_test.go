@@ -0,0 +1,100 @@
+package browser
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+var sitemapURL = regexp.MustCompile(`new URL\("([^"]+)"`)
+
+// sitemapSender serves the sitemaps in files, keyed by URL, as the page's
+// fetch would, and calls onFetch, if set, before each fetch.
+func sitemapSender(t *testing.T, files map[string][]byte, onFetch func(url string)) RequestSender {
+	return senderFunc(func(method string, params any) (*mcp.Message, error) {
+		script := params.(map[string]any)["script"].(string)
+		url := sitemapURL.FindStringSubmatch(script)[1]
+		if onFetch != nil {
+			onFetch(url)
+		}
+		data, ok := files[url]
+		if !ok {
+			return scriptResult(t, map[string]any{"error": "Sitemap returned HTTP 404"}), nil
+		}
+		return scriptResult(t, map[string]any{"data": base64.StdEncoding.EncodeToString(data)}), nil
+	})
+}
+
+func newSitemapController(sender RequestSender) *Controller {
+	return NewController(sender, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+}
+
+func sitemapIndex(children int) []byte {
+	var b strings.Builder
+	b.WriteString("<sitemapindex>")
+	for i := range children {
+		fmt.Fprintf(&b, "<sitemap><loc>/sitemap-%d.xml</loc></sitemap>", i)
+	}
+	b.WriteString("</sitemapindex>")
+	return []byte(b.String())
+}
+
+func TestGetSitemapCapsFetches(t *testing.T) {
+	files := map[string][]byte{"/sitemap.xml": sitemapIndex(200)}
+	for i := range 200 {
+		files[fmt.Sprintf("/sitemap-%d.xml", i)] = []byte(fmt.Sprintf("<urlset><url><loc>https://example.com/%d</loc></url></urlset>", i))
+	}
+	fetches := 0
+	c := newSitemapController(sitemapSender(t, files, func(string) { fetches++ }))
+
+	entries, err := c.GetSitemap(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetSitemap: %v", err)
+	}
+	if fetches != maxSitemapFetches {
+		t.Errorf("fetched %d sitemaps, want %d", fetches, maxSitemapFetches)
+	}
+	if len(entries) != maxSitemapFetches-1 {
+		t.Errorf("got %d entries, want %d", len(entries), maxSitemapFetches-1)
+	}
+}
+
+func TestGetSitemapStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetches := 0
+	c := newSitemapController(sitemapSender(t, map[string][]byte{"/sitemap.xml": sitemapIndex(10)}, func(string) {
+		if fetches++; fetches == 2 {
+			cancel()
+		}
+	}))
+
+	if _, err := c.GetSitemap(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if fetches > 2 {
+		t.Errorf("fetched %d sitemaps after cancellation, want 2", fetches)
+	}
+}
+
+func TestGetSitemapRejectsGzipBomb(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(make([]byte, maxSitemapSize+1))
+	zw.Close()
+	c := newSitemapController(sitemapSender(t, map[string][]byte{"/sitemap.xml": buf.Bytes()}, nil))
+
+	if _, err := c.GetSitemap(context.Background(), 1); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Fatalf("err = %v, want ErrPayloadTooLarge", err)
+	}
+}
@@ -0,0 +1,120 @@
+// Package browser implements named, reusable page scripts.
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// SnippetRegistry holds named scripts that can be run by name instead of
+// sending the full source with every request. Scripts are text/template
+// templates executed with the caller's args; use {{json .name}} to insert
+// an argument as a JavaScript literal.
+type SnippetRegistry struct {
+	mu       sync.RWMutex
+	snippets map[string]string
+}
+
+// NewSnippetRegistry creates a registry containing the built-in snippets.
+func NewSnippetRegistry() *SnippetRegistry {
+	r := &SnippetRegistry{snippets: make(map[string]string)}
+	for name, script := range builtinSnippets {
+		r.Register(name, script)
+	}
+	return r
+}
+
+// Register adds or replaces a snippet.
+func (r *SnippetRegistry) Register(name, script string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snippets[name] = script
+}
+
+// Get returns the script registered under name.
+func (r *SnippetRegistry) Get(name string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	script, ok := r.snippets[name]
+	return script, ok
+}
+
+// Names returns the registered snippet names in sorted order.
+func (r *SnippetRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.snippets))
+	for name := range r.snippets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithSnippetRegistry sets the registry used by RunSnippet.
+func WithSnippetRegistry(r *SnippetRegistry) Option {
+	return func(c *Controller) {
+		if r != nil {
+			c.snippets = r
+		}
+	}
+}
+
+// snippetFuncs are available inside snippet templates.
+var snippetFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// RunSnippet renders the named snippet with args and executes it in a tab.
+func (c *Controller) RunSnippet(ctx context.Context, tabID int, snippetName string, args map[string]any) (any, error) {
+	source, ok := c.snippets.Get(snippetName)
+	if !ok {
+		return nil, fmt.Errorf("unknown snippet %q (available: %s)", snippetName, strings.Join(c.snippets.Names(), ", "))
+	}
+
+	tmpl, err := template.New(snippetName).Funcs(snippetFuncs).Option("missingkey=zero").Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snippet %q: %w", snippetName, err)
+	}
+	var script strings.Builder
+	if err := tmpl.Execute(&script, args); err != nil {
+		return nil, fmt.Errorf("failed to render snippet %q: %w", snippetName, err)
+	}
+
+	result, err := c.ExecuteScript(ctx, tabID, script.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// builtinSnippets are registered in every new SnippetRegistry.
+var builtinSnippets = map[string]string{
+	// extract_tables returns every table (or those matching the optional
+	// selector arg) as an array of rows of cell text.
+	"extract_tables": `
+		Array.from(document.querySelectorAll({{with .selector}}{{json .}}{{else}}'table'{{end}})).map(table =>
+			Array.from(table.rows).map(row =>
+				Array.from(row.cells).map(cell => cell.innerText.trim())))
+	`,
+	// form_values returns the name/value pairs of the form matching the
+	// optional selector arg (default: the first form).
+	"form_values": `
+		(() => {
+			const form = document.querySelector({{with .selector}}{{json .}}{{else}}'form'{{end}});
+			if (!form) return { error: 'Form not found', code: 'not_found' };
+			return Object.fromEntries(new FormData(form));
+		})()
+	`,
+}
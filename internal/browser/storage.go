@@ -0,0 +1,88 @@
+// Package browser implements Web Storage operations.
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// storageJS returns the JavaScript expression for a storage type: "local"
+// (default) for localStorage or "session" for sessionStorage.
+func storageJS(storageType string) (string, error) {
+	switch storageType {
+	case "", "local":
+		return "window.localStorage", nil
+	case "session":
+		return "window.sessionStorage", nil
+	default:
+		return "", fmt.Errorf("invalid storageType %q: must be local or session", storageType)
+	}
+}
+
+// ListStorageKeys returns all keys in a tab's localStorage or sessionStorage.
+func (c *Controller) ListStorageKeys(ctx context.Context, tabID int, storageType string) ([]string, error) {
+	storage, err := storageJS(storageType)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.ExecuteScript(ctx, tabID, fmt.Sprintf("Object.keys(%s)", storage))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal storage keys: %w", err)
+	}
+	return keys, nil
+}
+
+// ClearStorage removes all entries from a tab's localStorage or
+// sessionStorage.
+func (c *Controller) ClearStorage(ctx context.Context, tabID int, storageType string) error {
+	storage, err := storageJS(storageType)
+	if err != nil {
+		return err
+	}
+	_, err = c.ExecuteScript(ctx, tabID, fmt.Sprintf("(() => { %s.clear(); return true; })()", storage))
+	return err
+}
+
+// GetStorageQuota reports the storage usage and quota of the tab's origin
+// from navigator.storage.estimate(). The per-type breakdown is only
+// available on Chromium. Returns ErrUnsupported if the StorageManager API
+// is unavailable, e.g. on pages not served over a secure context.
+func (c *Controller) GetStorageQuota(ctx context.Context, tabID int) (*mcp.StorageQuota, error) {
+	script := `
+		(async () => {
+			if (!navigator.storage?.estimate) {
+				return { error: 'StorageManager API not available', code: 'unsupported' };
+			}
+			const estimate = await navigator.storage.estimate();
+			return {
+				usage: estimate.usage || 0,
+				quota: estimate.quota || 0,
+				persistent: await navigator.storage.persisted?.() || false,
+				breakdown: estimate.usageDetails || {}
+			};
+		})()
+	`
+	result, err := c.ExecuteScript(ctx, tabID, script)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(result); err != nil {
+		return nil, err
+	}
+
+	var quota mcp.StorageQuota
+	data, _ := json.Marshal(result)
+	if err := json.Unmarshal(data, &quota); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal storage quota: %w", err)
+	}
+	return &quota, nil
+}
@@ -0,0 +1,241 @@
+// Package browser implements operations that wait for page state.
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// pollInterval is how often wait operations re-check their condition.
+const pollInterval = 200 * time.Millisecond
+
+// maxWaitTimeout keeps wait operations within the 30s limit on a tool call.
+const maxWaitTimeout = maxAsyncScriptTimeout * time.Millisecond
+
+// poll calls check every pollInterval until it reports true, the timeout,
+// capped at maxWaitTimeout, expires or ctx is cancelled. Transient errors
+// from check, raised while a page's frame is being replaced, are treated
+// as "not yet"; any other error is returned at once.
+func (c *Controller) poll(ctx context.Context, timeout time.Duration, check func() (bool, error)) error {
+	deadline := time.NewTimer(min(timeout, maxWaitTimeout))
+	defer deadline.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		ok, err := check()
+		if err != nil && !transientScriptError(err) {
+			return err
+		}
+		if err == nil && ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return ErrTimeout
+		case <-ticker.C:
+		}
+	}
+}
+
+// transientScriptError reports whether err is the extension failing to
+// inject a script because the tab's frame was torn down by a navigation.
+func transientScriptError(err error) bool {
+	var e *mcp.Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	msg := strings.ToLower(e.Message)
+	return strings.Contains(msg, "was removed") || strings.Contains(msg, "no frame with id")
+}
+
+// WaitForNavigation waits until the page in a tab reaches the given load
+// state: "load" (default), "DOMContentLoaded" or "networkidle", the latter
+// meaning loaded with no network activity for 500 ms.
+func (c *Controller) WaitForNavigation(ctx context.Context, tabID int, timeout time.Duration, waitUntil string) error {
+	var script string
+	switch waitUntil {
+	case "", "load":
+		script = `document.readyState === 'complete'`
+	case "DOMContentLoaded":
+		script = `document.readyState !== 'loading'`
+	case "networkidle":
+		script = `
+			(() => {
+				if (window.__mcpLastNetworkActivity === undefined) {
+					window.__mcpLastNetworkActivity = performance.now();
+					new PerformanceObserver(() => {
+						window.__mcpLastNetworkActivity = performance.now();
+					}).observe({ type: 'resource', buffered: true });
+				}
+				return document.readyState === 'complete' &&
+					performance.now() - window.__mcpLastNetworkActivity >= 500;
+			})()
+		`
+	default:
+		return fmt.Errorf("invalid waitUntil %q: must be load, DOMContentLoaded or networkidle", waitUntil)
+	}
+
+	err := c.poll(ctx, timeout, func() (bool, error) {
+		result, err := c.ExecuteScript(ctx, tabID, script)
+		if err != nil {
+			return false, err
+		}
+		if err := resultError(result); err != nil {
+			return false, err
+		}
+		ready, _ := result.(bool)
+		return ready, nil
+	})
+	if err == ErrTimeout {
+		return fmt.Errorf("waiting for %s: %w", waitUntil, err)
+	}
+	return err
+}
+
+// WaitForURL waits until a tab's URL matches the glob pattern, in which *
+// also matches /, and returns the matching URL. See compileURLGlob.
+func (c *Controller) WaitForURL(ctx context.Context, tabID int, pattern string, timeout time.Duration) (string, error) {
+	glob, err := compileURLGlob(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	var url string
+	err = c.poll(ctx, timeout, func() (bool, error) {
+		result, err := c.ExecuteScript(ctx, tabID, "window.location.href")
+		if err != nil {
+			return false, err
+		}
+		if err := resultError(result); err != nil {
+			return false, err
+		}
+		href, _ := result.(string)
+		if !glob.MatchString(href) {
+			return false, nil
+		}
+		url = href
+		return true, nil
+	})
+	if err == ErrTimeout {
+		return "", fmt.Errorf("waiting for URL %q: %w", pattern, err)
+	}
+	return url, err
+}
+
+// WaitForText waits until text appears in the page's visible text, or, when
+// present is false, until it no longer does.
+func (c *Controller) WaitForText(ctx context.Context, tabID int, text string, present bool, timeout time.Duration) error {
+	script := fmt.Sprintf(`(document.body?.innerText || '').includes(%q)`, text)
+	err := c.poll(ctx, timeout, func() (bool, error) {
+		result, err := c.ExecuteScript(ctx, tabID, script)
+		if err != nil {
+			return false, err
+		}
+		if err := resultError(result); err != nil {
+			return false, err
+		}
+		found, _ := result.(bool)
+		return found == present, nil
+	})
+	if err == ErrTimeout {
+		state := "appear"
+		if !present {
+			state = "disappear"
+		}
+		return fmt.Errorf("waiting for text %q to %s: %w", text, state, err)
+	}
+	return err
+}
+
+// WaitForNetworkIdle waits until the page has had no fetch or XHR calls
+// in flight for idleTime. Calls are counted by the hooks also used by
+// MonitorNetworkRequests; they are installed on the first check, so calls
+// already in flight then are not seen and idleTime is always waited at
+// least once. Returns ErrTimeout if the page does not go idle within
+// timeout, capped at 25 seconds.
+func (c *Controller) WaitForNetworkIdle(ctx context.Context, tabID int, idleTime, timeout time.Duration) error {
+	script := fmt.Sprintf(`
+		(() => {
+			%s
+			return window.__mcpInFlight === 0 && Date.now() - window.__mcpNetworkChange >= %d;
+		})()
+	`, networkHookJS, idleTime.Milliseconds())
+
+	err := c.poll(ctx, timeout, func() (bool, error) {
+		result, err := c.executeScriptInWorld(ctx, tabID, script, "MAIN")
+		if err != nil {
+			return false, err
+		}
+		if err := resultError(result); err != nil {
+			return false, err
+		}
+		idle, _ := result.(bool)
+		return idle, nil
+	})
+	if err == ErrTimeout {
+		return fmt.Errorf("waiting for network idle: %w", err)
+	}
+	return err
+}
+
+// navigationPendingJS marks the current document until it is replaced or,
+// for same-document navigations, the URL changes in place.
+const navigationPendingJS = `
+	(() => {
+		window.__mcpNavigationPending = true;
+		const clear = () => { window.__mcpNavigationPending = false; };
+		window.addEventListener('hashchange', clear, { once: true });
+		window.addEventListener('popstate', clear, { once: true });
+		return true;
+	})()
+`
+
+// focusNavigateTimeout bounds how long FocusAndNavigate waits for the load.
+const focusNavigateTimeout = 10 * time.Second
+
+// FocusAndNavigate activates a tab, navigates it to url and, if waitForLoad
+// is set, waits up to 10 seconds for the new document to finish loading.
+// The current document is marked before navigating so that its own
+// readyState is not mistaken for the new page's. The mark is cleared by
+// hashchange and popstate, as a same-document navigation such as a change
+// of fragment keeps the document.
+func (c *Controller) FocusAndNavigate(ctx context.Context, tabID int, url string, waitForLoad bool) error {
+	if err := c.ActivateTab(ctx, tabID); err != nil {
+		return err
+	}
+	if waitForLoad {
+		// Pages such as chrome:// URLs cannot be scripted; there is then
+		// no old document to confuse with the new one.
+		c.ExecuteScript(ctx, tabID, navigationPendingJS)
+	}
+	if err := c.NavigateTab(ctx, tabID, url); err != nil {
+		return err
+	}
+	if !waitForLoad {
+		return nil
+	}
+
+	err := c.poll(ctx, focusNavigateTimeout, func() (bool, error) {
+		result, err := c.ExecuteScript(ctx, tabID, "!window.__mcpNavigationPending && document.readyState === 'complete'")
+		if err != nil {
+			return false, err
+		}
+		if err := resultError(result); err != nil {
+			return false, err
+		}
+		loaded, _ := result.(bool)
+		return loaded, nil
+	})
+	if err == ErrTimeout {
+		return fmt.Errorf("waiting for %s to load: %w", url, err)
+	}
+	return err
+}
@@ -0,0 +1,145 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// senderFunc adapts a function to the RequestSender interface.
+type senderFunc func(method string, params any) (*mcp.Message, error)
+
+func (f senderFunc) SendRequest(method string, params any) (*mcp.Message, error) {
+	return f(method, params)
+}
+
+// scriptResult wraps value the way the extension reports the result of
+// browser.scripting.executeScript.
+func scriptResult(t *testing.T, value any) *mcp.Message {
+	t.Helper()
+	data, err := json.Marshal([]map[string]any{{"result": value}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &mcp.Message{Result: data}
+}
+
+func TestWaitForNavigationRetriesRemovedFrame(t *testing.T) {
+	calls := 0
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		calls++
+		if calls == 1 {
+			return &mcp.Message{Error: &mcp.Error{Code: -32603, Message: "Frame with ID 0 was removed."}}, nil
+		}
+		return scriptResult(t, true), nil
+	}))
+
+	if err := c.WaitForNavigation(context.Background(), 1, time.Second, "load"); err != nil {
+		t.Fatalf("WaitForNavigation: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestWaitForNavigationReturnsPermanentErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *mcp.Message
+	}{
+		{"closed tab", &mcp.Message{Error: &mcp.Error{Code: -32603, Message: "No tab with id: 1."}}},
+		{"blocked script", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := tt.resp
+			if resp == nil {
+				resp = scriptResult(t, map[string]any{"error": "Refused to evaluate a string as JavaScript because 'unsafe-eval' is not allowed"})
+			}
+			calls := 0
+			c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+				calls++
+				return resp, nil
+			}))
+
+			start := time.Now()
+			err := c.WaitForNavigation(context.Background(), 1, 5*time.Second, "load")
+			if err == nil || errors.Is(err, ErrTimeout) {
+				t.Fatalf("err = %v, want the check's error", err)
+			}
+			if calls != 1 || time.Since(start) > time.Second {
+				t.Errorf("returned after %d calls and %v, want at once", calls, time.Since(start))
+			}
+		})
+	}
+}
+
+func TestWaitForNetworkIdleReturnsScriptErrors(t *testing.T) {
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		return scriptResult(t, map[string]any{"error": "window.__mcpInFlight is read-only"}), nil
+	}))
+
+	err := c.WaitForNetworkIdle(context.Background(), 1, 500*time.Millisecond, 5*time.Second)
+	if err == nil || errors.Is(err, ErrTimeout) {
+		t.Fatalf("err = %v, want the script's error", err)
+	}
+}
+
+func TestObserveMutationsCapsDuration(t *testing.T) {
+	var script string
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		script = params.(map[string]any)["script"].(string)
+		return scriptResult(t, map[string]any{"mutations": []any{}}), nil
+	}))
+
+	if _, err := c.ObserveMutations(context.Background(), 1, "", time.Minute); err != nil {
+		t.Fatalf("ObserveMutations: %v", err)
+	}
+	if !strings.Contains(script, "}, 25000);") {
+		t.Errorf("script does not observe for 25000ms:\n%s", script)
+	}
+}
+
+func TestFocusAndNavigateToFragment(t *testing.T) {
+	// A fake page whose document survives a fragment navigation: only a
+	// hashchange listener installed by the marking script clears the mark.
+	var pending, clearOnHashChange bool
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		p := params.(map[string]any)
+		if method == "browser.tabs.update" {
+			if props := p["props"].(map[string]any); props["url"] != nil && clearOnHashChange {
+				pending = false
+			}
+			return &mcp.Message{Result: json.RawMessage(`{}`)}, nil
+		}
+		script := p["script"].(string)
+		if strings.Contains(script, "__mcpNavigationPending = true") {
+			pending = true
+			clearOnHashChange = strings.Contains(script, "addEventListener('hashchange'")
+			return scriptResult(t, true), nil
+		}
+		return scriptResult(t, !pending), nil
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := c.FocusAndNavigate(ctx, 1, "https://example.com/#section", true); err != nil {
+		t.Fatalf("FocusAndNavigate: %v", err)
+	}
+}
+
+func TestWaitForURLCrossesSlashes(t *testing.T) {
+	c := NewController(senderFunc(func(method string, params any) (*mcp.Message, error) {
+		return scriptResult(t, "https://example.com/a/b?q=1"), nil
+	}))
+
+	url, err := c.WaitForURL(context.Background(), 1, "https://example.com/*", time.Second)
+	if err != nil || url != "https://example.com/a/b?q=1" {
+		t.Errorf("WaitForURL = %q, %v", url, err)
+	}
+}
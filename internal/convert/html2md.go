@@ -0,0 +1,342 @@
+// Package convert converts page HTML into Markdown for LLM consumption.
+// It is a lightweight, standard-library-only converter that handles common
+// structure (headings, paragraphs, lists, links, emphasis and code) rather
+// than a complete HTML parser.
+package convert
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// dropPatterns match elements removed together with their content.
+	dropPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?is)<!--.*?-->`),
+		regexp.MustCompile(`(?is)<script\b.*?</script\s*>`),
+		regexp.MustCompile(`(?is)<style\b.*?</style\s*>`),
+		regexp.MustCompile(`(?is)<noscript\b.*?</noscript\s*>`),
+		regexp.MustCompile(`(?is)<template\b.*?</template\s*>`),
+		regexp.MustCompile(`(?is)<head\b.*?</head\s*>`),
+	}
+	attrPattern  = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	spacePattern = regexp.MustCompile(`\s+`)
+	blankLines   = regexp.MustCompile(`\n{3,}`)
+)
+
+// blockTags are separated from surrounding content by a blank line.
+var blockTags = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "header": true,
+	"footer": true, "main": true, "nav": true, "aside": true, "table": true,
+	"form": true, "figure": true, "blockquote": true, "dl": true, "address": true,
+}
+
+// HTMLToMarkdown converts an HTML document or fragment to Markdown.
+// Relative link and image URLs are resolved against baseURL if it is set.
+func HTMLToMarkdown(htmlText, baseURL string) string {
+	for _, re := range dropPatterns {
+		htmlText = re.ReplaceAllString(htmlText, "")
+	}
+
+	c := &converter{}
+	if base, err := url.Parse(baseURL); err == nil && baseURL != "" {
+		c.base = base
+	}
+
+	for i := 0; i < len(htmlText); {
+		if htmlText[i] == '<' {
+			end := tagEnd(htmlText, i+1)
+			if end < 0 {
+				c.text(htmlText[i:])
+				break
+			}
+			c.tag(htmlText[i+1 : end])
+			i = end + 1
+			continue
+		}
+		next := strings.IndexByte(htmlText[i:], '<')
+		if next < 0 {
+			next = len(htmlText) - i
+		}
+		c.text(htmlText[i : i+next])
+		i += next
+	}
+
+	lines := strings.Split(string(c.buf), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	out := blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(out) + "\n"
+}
+
+// tagEnd returns the index of the '>' closing the tag that starts at i,
+// skipping quoted attribute values, or -1 if there is none.
+func tagEnd(s string, i int) int {
+	var quote byte
+	for ; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '>':
+			return i
+		}
+	}
+	return -1
+}
+
+type list struct {
+	ordered bool
+	n       int
+	indent  int // width of the current item's marker, nesting included
+}
+
+type table struct {
+	rows  int // completed rows
+	cells int // cells in the current row
+}
+
+type converter struct {
+	buf    []byte
+	base   *url.URL
+	pre    int
+	lists  []list
+	tables []table
+	links  []string
+	// afterMarker is set while nothing has followed a list item marker
+	afterMarker bool
+}
+
+func (c *converter) write(s string) {
+	c.buf = append(c.buf, s...)
+	c.afterMarker = false
+}
+
+// block separates a block element from the surrounding content. Inside a
+// list item it starts an indented line instead of a paragraph, so that the
+// item goes on, and right after the item marker it adds nothing. Inside a
+// table it adds nothing, so that the row stays on one line.
+func (c *converter) block() {
+	switch {
+	case len(c.tables) > 0:
+	case len(c.lists) > 0:
+		if c.afterMarker {
+			return
+		}
+		c.newlines(1)
+		c.write(strings.Repeat(" ", c.lists[len(c.lists)-1].indent))
+	default:
+		c.newlines(2)
+	}
+}
+
+// endRow ends the current table row, following the first row with the
+// separator row Markdown tables need.
+func (c *converter) endRow() {
+	if len(c.tables) == 0 {
+		return
+	}
+	t := &c.tables[len(c.tables)-1]
+	if t.cells == 0 {
+		return
+	}
+	t.rows++
+	if t.rows == 1 {
+		c.newlines(1)
+		c.write(strings.Repeat("--- | ", t.cells-1) + "---")
+	}
+	t.cells = 0
+}
+
+// newlines ends the current line and ensures at least n newlines precede
+// the next output. Nothing is added at the start of the document.
+func (c *converter) newlines(n int) {
+	for len(c.buf) > 0 && c.buf[len(c.buf)-1] == ' ' {
+		c.buf = c.buf[:len(c.buf)-1]
+	}
+	if len(c.buf) == 0 {
+		return
+	}
+	have := 0
+	for i := len(c.buf) - 1; i >= 0 && c.buf[i] == '\n' && have < n; i-- {
+		have++
+	}
+	for ; have < n; have++ {
+		c.buf = append(c.buf, '\n')
+	}
+}
+
+func (c *converter) atLineStart() bool {
+	return len(c.buf) == 0 || c.buf[len(c.buf)-1] == '\n'
+}
+
+func (c *converter) text(raw string) {
+	t := html.UnescapeString(raw)
+	if c.pre > 0 {
+		c.write(t)
+		return
+	}
+	t = spacePattern.ReplaceAllString(t, " ")
+	if t == " " || t == "" {
+		if !c.atLineStart() && c.buf[len(c.buf)-1] != ' ' {
+			c.write(" ")
+		}
+		return
+	}
+	if strings.HasPrefix(t, " ") && (c.atLineStart() || c.buf[len(c.buf)-1] == ' ') {
+		t = t[1:]
+	}
+	c.write(t)
+}
+
+func (c *converter) resolve(ref string) string {
+	if c.base == nil {
+		return ref
+	}
+	u, err := c.base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return u.String()
+}
+
+func (c *converter) tag(raw string) {
+	if strings.HasPrefix(raw, "!") || strings.HasPrefix(raw, "?") {
+		return
+	}
+	closing := strings.HasPrefix(raw, "/")
+	raw = strings.TrimPrefix(raw, "/")
+	name := raw
+	if i := strings.IndexAny(raw, " \t\r\n/"); i >= 0 {
+		name = raw[:i]
+	}
+	name = strings.ToLower(name)
+
+	switch {
+	case len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6':
+		c.block()
+		if !closing {
+			c.write(strings.Repeat("#", int(name[1]-'0')) + " ")
+		}
+	case name == "table":
+		if closing {
+			c.endRow()
+			if len(c.tables) > 0 {
+				c.tables = c.tables[:len(c.tables)-1]
+			}
+		} else {
+			c.tables = append(c.tables, table{})
+		}
+		c.newlines(2)
+	case blockTags[name]:
+		c.block()
+	case name == "br":
+		c.write("\n")
+	case name == "hr":
+		c.newlines(2)
+		c.write("---")
+		c.newlines(2)
+	case name == "tr":
+		c.endRow()
+		c.newlines(1)
+	case name == "td" || name == "th":
+		if closing {
+			return
+		}
+		if !c.atLineStart() {
+			c.write(" | ")
+		}
+		if len(c.tables) > 0 {
+			c.tables[len(c.tables)-1].cells++
+		}
+	case name == "ul" || name == "ol":
+		if closing {
+			if len(c.lists) > 0 {
+				c.lists = c.lists[:len(c.lists)-1]
+			}
+			if len(c.lists) == 0 {
+				c.newlines(2)
+			} else {
+				c.newlines(1)
+			}
+		} else {
+			c.newlines(1)
+			c.lists = append(c.lists, list{ordered: name == "ol"})
+		}
+	case name == "li":
+		if closing || len(c.lists) == 0 {
+			return
+		}
+		c.newlines(1)
+		l := &c.lists[len(c.lists)-1]
+		marker := strings.Repeat("  ", len(c.lists)-1) + "- "
+		if l.ordered {
+			l.n++
+			marker = strings.Repeat("  ", len(c.lists)-1) + fmt.Sprintf("%d. ", l.n)
+		}
+		c.write(marker)
+		l.indent = len(marker)
+		c.afterMarker = true
+	case name == "a":
+		if closing {
+			if len(c.links) == 0 {
+				return
+			}
+			href := c.links[len(c.links)-1]
+			c.links = c.links[:len(c.links)-1]
+			if href != "" {
+				c.write("](" + href + ")")
+			}
+			return
+		}
+		href := attr(raw, "href")
+		if href == "" || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			c.links = append(c.links, "")
+			return
+		}
+		c.links = append(c.links, c.resolve(href))
+		c.write("[")
+	case name == "strong" || name == "b":
+		c.write("**")
+	case name == "em" || name == "i":
+		c.write("_")
+	case name == "code":
+		if c.pre == 0 {
+			c.write("`")
+		}
+	case name == "pre":
+		if closing {
+			if c.pre > 0 {
+				c.pre--
+			}
+			c.newlines(1)
+			c.write("```")
+			c.newlines(2)
+		} else {
+			c.newlines(2)
+			c.write("```\n")
+			c.pre++
+		}
+	case name == "img":
+		if src := attr(raw, "src"); src != "" {
+			c.write("![" + attr(raw, "alt") + "](" + c.resolve(src) + ")")
+		}
+	}
+}
+
+// attr returns the unescaped value of the named attribute in a raw tag.
+func attr(raw, name string) string {
+	for _, m := range attrPattern.FindAllStringSubmatch(raw, -1) {
+		if strings.EqualFold(m[1], name) {
+			return html.UnescapeString(m[2] + m[3] + m[4])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package convert
+
+import "testing"
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "headings and paragraphs",
+			html: "<h1>Title</h1><p>One <b>bold</b> and <em>em</em>.</p><p>Two</p>",
+			want: "# Title\n\nOne **bold** and _em_.\n\nTwo\n",
+		},
+		{
+			name: "dropped elements",
+			html: "<head><title>x</title></head><script>alert(1)</script><!-- c --><p>Text</p>",
+			want: "Text\n",
+		},
+		{
+			name: "links and images",
+			html: `<a href="/docs">Docs</a> <a href="javascript:void(0)">JS</a> <img src="a.png" alt="A">`,
+			want: "[Docs](https://example.com/docs) JS ![A](https://example.com/a.png)\n",
+		},
+		{
+			name: "lists",
+			html: "<ul><li>a</li><li>b<ol><li>c</li><li>d</li></ol></li></ul>",
+			want: "- a\n- b\n  1. c\n  2. d\n",
+		},
+		{
+			name: "paragraphs in list items",
+			html: "<ol>\n<li><p>one</p></li>\n<li><p>two</p><p>more</p></li>\n</ol><p>after</p>",
+			want: "1. one\n2. two\n   more\n\nafter\n",
+		},
+		{
+			name: "table",
+			html: "<table><tr><th>A</th><th>B</th></tr><tr><td>1</td><td>2</td></tr><tr><td>3</td><td>4</td></tr></table>",
+			want: "A | B\n--- | ---\n1 | 2\n3 | 4\n",
+		},
+		{
+			name: "table without closing tags or with blocks in cells",
+			html: "<table><tr><td><p>x</p><td>y</table>",
+			want: "x | y\n--- | ---\n",
+		},
+		{
+			name: "preformatted",
+			html: "<pre><code>a  b\n  c</code></pre><p>Use <code>go test</code></p>",
+			want: "```\na  b\n  c\n```\n\nUse `go test`\n",
+		},
+		{
+			name: "entities",
+			html: "<p>a &amp; b &lt;c&gt;</p>",
+			want: "a & b <c>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTMLToMarkdown(tt.html, "https://example.com/page"); got != tt.want {
+				t.Errorf("HTMLToMarkdown(%q) =\n%q\nwant\n%q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Package mcp defines the JSON-RPC request ID type.
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// IDValue is a JSON-RPC request ID, which may be either an integer or a
+// string. The zero value represents an absent or null ID. IDValue is
+// comparable and can be used as a map key.
+type IDValue struct {
+	num   int64
+	str   string
+	isStr bool
+	set   bool
+}
+
+// NumberID returns an integer ID.
+func NumberID(n int64) IDValue {
+	return IDValue{num: n, set: true}
+}
+
+// StringID returns a string ID.
+func StringID(s string) IDValue {
+	return IDValue{str: s, isStr: true, set: true}
+}
+
+// ParseIDValue parses a raw JSON ID. null or empty input yields the zero
+// value; anything other than an integer or string is an error.
+func ParseIDValue(raw json.RawMessage) (IDValue, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return IDValue{}, nil
+	}
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return IDValue{}, fmt.Errorf("invalid id %s: %w", raw, err)
+		}
+		return StringID(s), nil
+	}
+	n, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return IDValue{}, fmt.Errorf("invalid id %s: must be an integer or string", raw)
+	}
+	return NumberID(n), nil
+}
+
+// IsSet reports whether the ID is present and not null.
+func (id IDValue) IsSet() bool {
+	return id.set
+}
+
+// String returns the ID in its JSON form without quotes, or "null".
+func (id IDValue) String() string {
+	switch {
+	case !id.set:
+		return "null"
+	case id.isStr:
+		return id.str
+	default:
+		return strconv.FormatInt(id.num, 10)
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (id IDValue) MarshalJSON() ([]byte, error) {
+	switch {
+	case !id.set:
+		return []byte("null"), nil
+	case id.isStr:
+		return json.Marshal(id.str)
+	default:
+		return []byte(strconv.FormatInt(id.num, 10)), nil
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *IDValue) UnmarshalJSON(data []byte) error {
+	v, err := ParseIDValue(data)
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
@@ -3,12 +3,13 @@ package mcp
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
 // Message represents a generic MCP message.
 type Message struct {
-	ID     int             `json:"id"`
+	ID     IDValue         `json:"id"`
 	Method string          `json:"method,omitempty"`
 	Params json.RawMessage `json:"params,omitempty"`
 	Result json.RawMessage `json:"result,omitempty"`
@@ -22,6 +23,15 @@ type Error struct {
 	Data    any    `json:"data,omitempty"`
 }
 
+// ErrDisconnected is the error code for requests that fail because the
+// extension disconnected before responding.
+const ErrDisconnected = -32000
+
+// ErrNotSent is wrapped by errors for requests that never reached the
+// extension, e.g. because it was not connected. Such requests are safe to
+// retry.
+var ErrNotSent = errors.New("request not sent")
+
 func (e Error) Error() string {
 	return fmt.Sprintf("MCP error %d: %s", e.Code, e.Message)
 }
@@ -42,10 +52,15 @@ type Parameters struct {
 
 // Property describes a single parameter property.
 type Property struct {
-	Type        string `json:"type"`
-	Description string `json:"description"`
+	Type        string    `json:"type"`
+	Description string    `json:"description"`
+	Items       *Property `json:"items,omitempty"`
 }
 
+// selectorDescription documents selector parameters that accept text selectors
+// in addition to CSS.
+const selectorDescription = `CSS selector, or text="..." (exact text) / text*="..." (text contains)`
+
 // Tab represents a browser tab.
 type Tab struct {
 	ID       int    `json:"id"`
@@ -57,6 +72,16 @@ type Tab struct {
 	Pinned   bool   `json:"pinned"`
 	Audible  bool   `json:"audible"`
 	Status   string `json:"status"`
+
+	MutedInfo *MutedInfo `json:"mutedInfo,omitempty"`
+}
+
+// MutedInfo describes a tab's muted state.
+// Reason is "user", "capture" or "extension" when the tab is muted.
+type MutedInfo struct {
+	Muted       bool   `json:"muted"`
+	Reason      string `json:"reason,omitempty"`
+	ExtensionID string `json:"extensionId,omitempty"`
 }
 
 // ListTabsParams parameters for tabs/list.
@@ -108,10 +133,13 @@ type FillInputParams struct {
 }
 
 // ScrollPageParams parameters for page/scroll.
+// When Selector is set, the page scrolls to that element instead of X/Y.
 type ScrollPageParams struct {
-	TabID int `json:"tabId"`
-	X     int `json:"x"`
-	Y     int `json:"y"`
+	TabID    int    `json:"tabId"`
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+	Selector string `json:"selector,omitempty"`
+	Behavior string `json:"behavior,omitempty"`
 }
 
 // FindElementParams parameters for page/find.
@@ -149,8 +177,428 @@ type FindResult struct {
 	Elements []ElementInfo `json:"elements"`
 }
 
+// AccessibilityNode represents a node in the accessibility (AX) tree.
+type AccessibilityNode struct {
+	Role        string              `json:"role"`
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Children    []AccessibilityNode `json:"children,omitempty"`
+	Bounds      *Bounds             `json:"bounds,omitempty"`
+}
+
+// Bounds represents the position and size of a node in CSS pixels.
+type Bounds struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// XPathResult represents the typed result of an XPath expression.
+// ResultType is one of "number", "string", "boolean" or "nodes".
+type XPathResult struct {
+	ResultType string `json:"resultType"`
+	Value      any    `json:"value"`
+}
+
+// DomMutation represents a single DOM change recorded by a MutationObserver.
+type DomMutation struct {
+	Type           string `json:"type"`
+	TargetSelector string `json:"targetSelector"`
+	AttributeName  string `json:"attributeName,omitempty"`
+	AddedNodes     int    `json:"addedNodes"`
+	RemovedNodes   int    `json:"removedNodes"`
+}
+
+// Download represents a browser download item.
+// State is one of "in_progress", "complete" or "interrupted".
+type Download struct {
+	ID            int    `json:"id"`
+	URL           string `json:"url"`
+	Filename      string `json:"filename"`
+	State         string `json:"state"`
+	BytesReceived int64  `json:"bytesReceived"`
+	TotalBytes    int64  `json:"totalBytes"`
+	StartTime     string `json:"startTime"`
+}
+
+// BoundingRect represents an element's position and size in CSS pixels.
+type BoundingRect struct {
+	Top    float64 `json:"top"`
+	Left   float64 `json:"left"`
+	Bottom float64 `json:"bottom"`
+	Right  float64 `json:"right"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+}
+
+// TabOp describes a single operation in a batch. Type selects the operation
+// ("content", "screenshot", "navigate", "close", "execute" or "find"); the
+// remaining fields are used by the operations that need them.
+type TabOp struct {
+	Type     string `json:"type"`
+	TabID    int    `json:"tabId"`
+	URL      string `json:"url,omitempty"`
+	Script   string `json:"script,omitempty"`
+	Selector string `json:"selector,omitempty"`
+}
+
+// TabOpResult is the outcome of a single batched TabOp.
+type TabOpResult struct {
+	TabID  int    `json:"tabId"`
+	Type   string `json:"type"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// PendingDialog describes a JavaScript dialog that is currently open.
+// Type is one of "alert", "confirm", "prompt" or "beforeunload".
+type PendingDialog struct {
+	Type          string `json:"type"`
+	Message       string `json:"message"`
+	DefaultPrompt string `json:"defaultPrompt,omitempty"`
+}
+
+// Bookmark represents a bookmark or bookmark folder.
+// Folders have no URL and may have children.
+type Bookmark struct {
+	ID        string     `json:"id"`
+	ParentID  string     `json:"parentId,omitempty"`
+	Title     string     `json:"title"`
+	URL       string     `json:"url,omitempty"`
+	DateAdded int64      `json:"dateAdded,omitempty"`
+	Children  []Bookmark `json:"children,omitempty"`
+}
+
+// IframeInfo describes an iframe element in a page.
+type IframeInfo struct {
+	Selector string `json:"selector"`
+	Src      string `json:"src"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+}
+
+// ConsoleLog represents a captured console message.
+// Level is one of "log", "info", "warn", "error" or "debug".
+type ConsoleLog struct {
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+	Timestamp int64  `json:"timestamp"`
+	Source    string `json:"source"`
+}
+
+// MetaTag represents a <meta> element. Only the attributes present on the
+// element are set.
+type MetaTag struct {
+	Name      string `json:"name,omitempty"`
+	Property  string `json:"property,omitempty"`
+	HTTPEquiv string `json:"httpEquiv,omitempty"`
+	Content   string `json:"content,omitempty"`
+	Charset   string `json:"charset,omitempty"`
+}
+
+// NetworkRequest represents an XHR or fetch call made by the page.
+// Bodies are truncated to 1 KB; Status is 0 if no response was received.
+type NetworkRequest struct {
+	URL          string `json:"url"`
+	Method       string `json:"method"`
+	Status       int    `json:"status"`
+	RequestBody  string `json:"requestBody"`
+	ResponseBody string `json:"responseBody"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+// PDFOptions controls PDF export. Sizes are in inches; zero values use the
+// browser's defaults.
+type PDFOptions struct {
+	Landscape       bool    `json:"landscape,omitempty"`
+	PrintBackground bool    `json:"printBackground,omitempty"`
+	PaperWidth      float64 `json:"paperWidth,omitempty"`
+	PaperHeight     float64 `json:"paperHeight,omitempty"`
+	MarginTop       float64 `json:"marginTop,omitempty"`
+	MarginBottom    float64 `json:"marginBottom,omitempty"`
+	MarginLeft      float64 `json:"marginLeft,omitempty"`
+	MarginRight     float64 `json:"marginRight,omitempty"`
+}
+
+// DeviceProfile describes a device to emulate.
+type DeviceProfile struct {
+	UserAgent        string  `json:"userAgent"`
+	Width            int     `json:"width"`
+	Height           int     `json:"height"`
+	DevicePixelRatio float64 `json:"devicePixelRatio"`
+	Mobile           bool    `json:"mobile"`
+}
+
+// DeviceProfiles are common device profiles keyed by name.
+var DeviceProfiles = map[string]DeviceProfile{
+	"iPhone 14": {
+		UserAgent:        "Mozilla/5.0 (iPhone; CPU iPhone OS 16_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.0 Mobile/15E148 Safari/604.1",
+		Width:            390,
+		Height:           844,
+		DevicePixelRatio: 3,
+		Mobile:           true,
+	},
+	"iPhone SE": {
+		UserAgent:        "Mozilla/5.0 (iPhone; CPU iPhone OS 16_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.0 Mobile/15E148 Safari/604.1",
+		Width:            375,
+		Height:           667,
+		DevicePixelRatio: 2,
+		Mobile:           true,
+	},
+	"iPad": {
+		UserAgent:        "Mozilla/5.0 (iPad; CPU OS 16_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.0 Mobile/15E148 Safari/604.1",
+		Width:            810,
+		Height:           1080,
+		DevicePixelRatio: 2,
+		Mobile:           true,
+	},
+	"Pixel 7": {
+		UserAgent:        "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Mobile Safari/537.36",
+		Width:            412,
+		Height:           915,
+		DevicePixelRatio: 2.625,
+		Mobile:           true,
+	},
+	"Galaxy S21": {
+		UserAgent:        "Mozilla/5.0 (Linux; Android 13; SM-G991B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Mobile Safari/537.36",
+		Width:            360,
+		Height:           800,
+		DevicePixelRatio: 3,
+		Mobile:           true,
+	},
+	"Desktop 1080p": {
+		UserAgent:        "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Safari/537.36",
+		Width:            1920,
+		Height:           1080,
+		DevicePixelRatio: 1,
+		Mobile:           false,
+	},
+}
+
+// PageError represents an uncaught exception or unhandled promise rejection.
+type PageError struct {
+	Message   string `json:"message"`
+	Source    string `json:"source"`
+	Lineno    int    `json:"lineno"`
+	Colno     int    `json:"colno"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// InterceptedResponse is the response to an XHR or fetch call made by the
+// page. Body is base64-encoded when IsBase64 is set.
+type InterceptedResponse struct {
+	URL       string            `json:"url"`
+	Status    int               `json:"status"`
+	Headers   map[string]string `json:"headers"`
+	Body      string            `json:"body"`
+	IsBase64  bool              `json:"isBase64"`
+	Timestamp int64             `json:"timestamp"`
+}
+
+// TabFilter selects tabs. Unset fields match every tab.
+type TabFilter struct {
+	URLPattern    string `json:"urlPattern,omitempty"`
+	TitleContains string `json:"titleContains,omitempty"`
+	Status        string `json:"status,omitempty"`
+	Active        *bool  `json:"active,omitempty"`
+	Pinned        *bool  `json:"pinned,omitempty"`
+	Audible       *bool  `json:"audible,omitempty"`
+}
+
+// StructuredData holds the JSON-LD blocks and microdata items of a page.
+type StructuredData struct {
+	JSONLD    []json.RawMessage `json:"jsonLd"`
+	Microdata []MicrodataItem   `json:"microdata"`
+}
+
+// MicrodataItem is an itemscope element. Property values are strings or,
+// for nested items, MicrodataItem objects.
+type MicrodataItem struct {
+	Type       string           `json:"type,omitempty"`
+	ID         string           `json:"id,omitempty"`
+	Properties map[string][]any `json:"properties"`
+}
+
+// ScreenshotOptions controls the encoding of element screenshots.
+// Format is "png" (default) or "jpeg"; Quality (1-100) applies to JPEG.
+type ScreenshotOptions struct {
+	Format  string `json:"format,omitempty"`
+	Quality int    `json:"quality,omitempty"`
+}
+
+// ScrollState describes a page's scroll offset and scrollable size in CSS
+// pixels.
+type ScrollState struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// FormField describes a form control and its current value. Checked is set
+// for checkboxes and radio buttons, Options for selects.
+type FormField struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Selector string   `json:"selector"`
+	Value    string   `json:"value"`
+	Checked  *bool    `json:"checked,omitempty"`
+	Options  []string `json:"options,omitempty"`
+	Required bool     `json:"required"`
+}
+
+// LinkFilter selects links for GetLinks. URLPattern is a path.Match glob,
+// TextContains matches case-insensitively, and BaseURLOnly strips query
+// strings and fragments before duplicates are removed. Zero fields match
+// everything; a zero Limit returns all links.
+type LinkFilter struct {
+	URLPattern   string `json:"urlPattern,omitempty"`
+	TextContains string `json:"textContains,omitempty"`
+	Limit        int    `json:"limit,omitempty"`
+	BaseURLOnly  bool   `json:"baseURLOnly,omitempty"`
+}
+
+// ImageInfo describes an image element. Data holds the image as a data URL
+// when requested and the image is under 100 KB and fetchable.
+type ImageInfo struct {
+	Src           string `json:"src"`
+	Alt           string `json:"alt"`
+	Width         int    `json:"width"`
+	Height        int    `json:"height"`
+	NaturalWidth  int    `json:"naturalWidth"`
+	NaturalHeight int    `json:"naturalHeight"`
+	Loaded        bool   `json:"loaded"`
+	Data          string `json:"data,omitempty"`
+}
+
+// TableData holds a table's contents. Cells spanning several rows or
+// columns are repeated in each position they cover, and multi-row headers
+// are joined per column with " / ".
+type TableData struct {
+	Headers []string   `json:"headers"`
+	Rows    [][]string `json:"rows"`
+}
+
+// DropdownOption represents an option of a select element.
+type DropdownOption struct {
+	Value    string `json:"value"`
+	Text     string `json:"text"`
+	Selected bool   `json:"selected"`
+	Disabled bool   `json:"disabled"`
+}
+
+// Window represents a browser window.
+type Window struct {
+	ID      int    `json:"id"`
+	Focused bool   `json:"focused"`
+	State   string `json:"state,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Tabs    []Tab  `json:"tabs,omitempty"`
+}
+
+// TimeRange is a span of media time in seconds.
+type TimeRange struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// VideoInfo describes the state of a video element. Duration is 0 when it
+// is unknown or the stream is live.
+type VideoInfo struct {
+	Src          string      `json:"src"`
+	CurrentTime  float64     `json:"currentTime"`
+	Duration     float64     `json:"duration"`
+	Paused       bool        `json:"paused"`
+	Muted        bool        `json:"muted"`
+	Volume       float64     `json:"volume"`
+	ReadyState   int         `json:"readyState"`
+	NetworkState int         `json:"networkState"`
+	Width        int         `json:"width"`
+	Height       int         `json:"height"`
+	Buffered     []TimeRange `json:"buffered"`
+}
+
+// PerformanceMetrics holds page load timings. NavigationStart is a Unix
+// time in milliseconds; the other timings are milliseconds after it and 0
+// when not (yet) available. TransferSize is the document's size in bytes.
+type PerformanceMetrics struct {
+	NavigationStart        float64 `json:"navigationStart"`
+	DOMContentLoaded       float64 `json:"domContentLoaded"`
+	LoadEvent              float64 `json:"loadEvent"`
+	FirstContentfulPaint   float64 `json:"firstContentfulPaint"`
+	LargestContentfulPaint float64 `json:"largestContentfulPaint"`
+	TimeToFirstByte        float64 `json:"timeToFirstByte"`
+	DOMInteractive         float64 `json:"domInteractive"`
+	TransferSize           int     `json:"transferSize"`
+}
+
+// LighthouseResult holds Lighthouse category scores (0-100) and audit
+// diagnostics. Scores of categories that were not run are zero.
+type LighthouseResult struct {
+	Performance   float64        `json:"performance"`
+	Accessibility float64        `json:"accessibility"`
+	BestPractices float64        `json:"bestPractices"`
+	SEO           float64        `json:"seo"`
+	Diagnostics   map[string]any `json:"diagnostics,omitempty"`
+}
+
+// Color is an RGBA pixel color with a #rrggbb convenience form.
+type Color struct {
+	R   uint8  `json:"r"`
+	G   uint8  `json:"g"`
+	B   uint8  `json:"b"`
+	A   uint8  `json:"a"`
+	Hex string `json:"hex"`
+}
+
+// GeoPosition is one position of a simulated geolocation track.
+type GeoPosition struct {
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Accuracy  float64  `json:"accuracy"`
+	Altitude  *float64 `json:"altitude,omitempty"`
+}
+
+// SitemapEntry is a URL listed in an XML sitemap. Priority is zero when
+// the sitemap does not specify one.
+type SitemapEntry struct {
+	Loc        string  `json:"loc"`
+	LastMod    string  `json:"lastmod,omitempty"`
+	ChangeFreq string  `json:"changefreq,omitempty"`
+	Priority   float64 `json:"priority,omitempty"`
+}
+
+// ScreenshotDiff is the result of comparing a screenshot with a baseline.
+// DifferencePercent ranges from 0 to 100; DiffImageBase64 is a PNG.
+type ScreenshotDiff struct {
+	DifferencePercent float64 `json:"differencePercent"`
+	DiffImageBase64   string  `json:"diffImageBase64"`
+	Changed           bool    `json:"changed"`
+}
+
+// WorkerInfo describes a running Web Worker. Type is dedicated, shared or
+// service; Scope is set for service workers.
+type WorkerInfo struct {
+	ID    string `json:"id"`
+	URL   string `json:"url"`
+	Type  string `json:"type"`
+	Scope string `json:"scope,omitempty"`
+}
+
+// StorageQuota is the storage usage and quota of an origin, in bytes.
+// Breakdown maps storage types such as indexedDB and caches to their usage.
+type StorageQuota struct {
+	Usage      int64            `json:"usage"`
+	Quota      int64            `json:"quota"`
+	Persistent bool             `json:"persistent"`
+	Breakdown  map[string]int64 `json:"breakdown,omitempty"`
+}
+
 // SuccessResponse creates a success result message.
-func SuccessResponse(id int, result any) *Message {
+func SuccessResponse(id IDValue, result any) *Message {
 	data, _ := json.Marshal(result)
 	return &Message{
 		ID:     id,
@@ -159,7 +607,7 @@ func SuccessResponse(id int, result any) *Message {
 }
 
 // ErrorResponse creates an error result message.
-func ErrorResponse(id int, code int, message string) *Message {
+func ErrorResponse(id IDValue, code int, message string) *Message {
 	return &Message{
 		ID:    id,
 		Error: &Error{Code: code, Message: message},
@@ -167,7 +615,28 @@ func ErrorResponse(id int, code int, message string) *Message {
 }
 
 // GetTools returns the list of available MCP tools.
+// Every tool that takes a tabId also accepts tabUrl, used when tabId is -1.
 func GetTools() []Tool {
+	tools := toolDefinitions()
+	for _, t := range tools {
+		props := t.InputSchema.Properties
+		tabID, ok := props["tabId"]
+		if !ok {
+			continue
+		}
+		tabID.Description += " (-1 to use the first tab matching tabUrl)"
+		props["tabId"] = tabID
+		props["tabUrl"] = Property{Type: "string", Description: tabURLDescription}
+	}
+	return tools
+}
+
+// tabURLDescription documents the tabUrl parameter added to tab tools.
+const tabURLDescription = "Glob pattern matched against tab URLs when tabId is -1; * matches any characters including / (e.g., https://example.com/* matches https://example.com/a/b)"
+
+// toolDefinitions returns the tool definitions without the shared tabUrl
+// parameter.
+func toolDefinitions() []Tool {
 	return []Tool{
 		{
 			Name:        "browser_tabs_list",
@@ -232,12 +701,12 @@ func GetTools() []Tool {
 		},
 		{
 			Name:        "browser_page_click",
-			Description: "Click an element by CSS selector",
+			Description: "Click an element by CSS or text selector",
 			InputSchema: Parameters{
 				Type: "object",
 				Properties: map[string]Property{
 					"tabId":    {Type: "integer", Description: "ID of the tab"},
-					"selector": {Type: "string", Description: "CSS selector"},
+					"selector": {Type: "string", Description: selectorDescription},
 				},
 				Required: []string{"tabId", "selector"},
 			},
@@ -249,7 +718,7 @@ func GetTools() []Tool {
 				Type: "object",
 				Properties: map[string]Property{
 					"tabId":    {Type: "integer", Description: "ID of the tab"},
-					"selector": {Type: "string", Description: "CSS selector"},
+					"selector": {Type: "string", Description: selectorDescription},
 					"value":    {Type: "string", Description: "Value to fill"},
 				},
 				Required: []string{"tabId", "selector", "value"},
@@ -257,13 +726,14 @@ func GetTools() []Tool {
 		},
 		{
 			Name:        "browser_page_scroll",
-			Description: "Scroll the page",
+			Description: "Scroll the page to x/y, or to an element when selector is given",
 			InputSchema: Parameters{
 				Type: "object",
 				Properties: map[string]Property{
-					"tabId": {Type: "integer", Description: "ID of the tab"},
-					"x":     {Type: "integer", Description: "X scroll position"},
-					"y":     {Type: "integer", Description: "Y scroll position"},
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"x":        {Type: "integer", Description: "X scroll position"},
+					"y":        {Type: "integer", Description: "Y scroll position"},
+					"selector": {Type: "string", Description: "Element to scroll to (overrides x/y); " + selectorDescription},
 				},
 				Required: []string{"tabId"},
 			},
@@ -282,15 +752,1343 @@ func GetTools() []Tool {
 		},
 		{
 			Name:        "browser_page_find",
-			Description: "Find elements by CSS selector",
+			Description: "Find elements by CSS or text selector",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_accessibility_tree",
+			Description: "Get the accessibility (AX) tree of the page",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_evaluate_xpath",
+			Description: "Evaluate an XPath expression; returns a number, string, boolean or matching nodes",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":           {Type: "integer", Description: "ID of the tab"},
+					"expression":      {Type: "string", Description: "XPath expression, e.g. count(//li) or //h1"},
+					"contextSelector": {Type: "string", Description: "CSS selector of the context node (default: document)"},
+				},
+				Required: []string{"tabId", "expression"},
+			},
+		},
+		{
+			Name:        "browser_page_observe_mutations",
+			Description: "Observe DOM changes under an element for a period of time and return them",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"selector":  {Type: "string", Description: "Element to observe (default: body); " + selectorDescription},
+					"timeoutMs": {Type: "integer", Description: "How long to observe in milliseconds (default: 5000, max: 25000)"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_downloads_list",
+			Description: "List in-progress and completed downloads, most recent first",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"query": {Type: "string", Description: "Search term matched against URL and filename"},
+					"limit": {Type: "integer", Description: "Maximum number of downloads to return"},
+				},
+				Required: []string{},
+			},
+		},
+		{
+			Name:        "browser_downloads_open",
+			Description: "Open a completed download with the system handler",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"downloadId": {Type: "integer", Description: "ID of the download"},
+				},
+				Required: []string{"downloadId"},
+			},
+		},
+		{
+			Name:        "browser_downloads_cancel",
+			Description: "Cancel an in-progress download",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"downloadId": {Type: "integer", Description: "ID of the download"},
+				},
+				Required: []string{"downloadId"},
+			},
+		},
+		{
+			Name:        "browser_downloads_erase",
+			Description: "Remove a download from history (the file is kept on disk)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"downloadId": {Type: "integer", Description: "ID of the download"},
+				},
+				Required: []string{"downloadId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_bounding_rect",
+			Description: "Get an element's position and size (viewport-relative unless includeScrollOffset is set)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":               {Type: "integer", Description: "ID of the tab"},
+					"selector":            {Type: "string", Description: selectorDescription},
+					"includeScrollOffset": {Type: "boolean", Description: "Add the page scroll offset to get document-relative coordinates"},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_tabs_batch",
+			Description: "Run operations on many tabs concurrently; results keep the input order",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"ops": {
+						Type:        "array",
+						Description: "Operations: {type, tabId, url?, script?, selector?} where type is content, screenshot, navigate, close, execute or find",
+						Items:       &Property{Type: "object", Description: "Tab operation"},
+					},
+				},
+				Required: []string{"ops"},
+			},
+		},
+		{
+			Name:        "browser_page_scroll_to_element",
+			Description: "Scroll an element into view",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+					"behavior": {Type: "string", Description: "Scroll behavior: auto (default) or smooth"},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_check_checkbox",
+			Description: "Check a checkbox (no-op if already checked)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_uncheck_checkbox",
+			Description: "Uncheck a checkbox (no-op if already unchecked)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_focus",
+			Description: "Focus an element (e.g. before typing into it)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_blur",
+			Description: "Remove focus from an element",
 			InputSchema: Parameters{
 				Type: "object",
 				Properties: map[string]Property{
 					"tabId":    {Type: "integer", Description: "ID of the tab"},
-					"selector": {Type: "string", Description: "CSS selector"},
+					"selector": {Type: "string", Description: selectorDescription},
 				},
 				Required: []string{"tabId", "selector"},
 			},
 		},
+		{
+			Name:        "browser_page_alert_handle",
+			Description: "Accept or dismiss an open JavaScript alert/confirm/prompt dialog",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":      {Type: "integer", Description: "ID of the tab"},
+					"action":     {Type: "string", Description: "accept or dismiss"},
+					"promptText": {Type: "string", Description: "Text to enter into a prompt dialog before accepting"},
+				},
+				Required: []string{"tabId", "action"},
+			},
+		},
+		{
+			Name:        "browser_page_get_pending_dialog",
+			Description: "Get the JavaScript dialog currently open in a tab, if any",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_bookmarks_list",
+			Description: "Search bookmarks, or return the whole bookmark tree when no query is given",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"query": {Type: "string", Description: "Search term matched against titles and URLs"},
+				},
+				Required: []string{},
+			},
+		},
+		{
+			Name:        "browser_bookmarks_create",
+			Description: "Create a bookmark (or a folder when url is omitted)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"parentId": {Type: "string", Description: "ID of the parent folder (default: Other Bookmarks)"},
+					"title":    {Type: "string", Description: "Bookmark title"},
+					"url":      {Type: "string", Description: "Bookmark URL"},
+				},
+				Required: []string{"title"},
+			},
+		},
+		{
+			Name:        "browser_bookmarks_delete",
+			Description: "Delete a bookmark or an empty folder",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"id": {Type: "string", Description: "ID of the bookmark"},
+				},
+				Required: []string{"id"},
+			},
+		},
+		{
+			Name:        "browser_page_iframe_list",
+			Description: "List all iframes in the page",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_switch_to_iframe",
+			Description: "Get the content (text, HTML, links) of a same-origin iframe; cross-origin iframes are blocked",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":          {Type: "integer", Description: "ID of the tab"},
+					"iframeSelector": {Type: "string", Description: "Selector of the iframe element"},
+				},
+				Required: []string{"tabId", "iframeSelector"},
+			},
+		},
+		{
+			Name:        "browser_page_get_console_logs",
+			Description: "Get and clear console messages captured since the page loaded (or since the last read)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+					"level": {Type: "string", Description: "Only return this level: log, info, warn, error or debug"},
+					"limit": {Type: "integer", Description: "Maximum number of (most recent) entries to return"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_wait_for_navigation",
+			Description: "Wait until the page in a tab has finished loading",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"waitUntil": {Type: "string", Description: "Load state to wait for: load (default), DOMContentLoaded or networkidle"},
+					"timeoutMs": {Type: "integer", Description: "Maximum time to wait in milliseconds (default and max: 25000)"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_meta_tags",
+			Description: "Get all meta tags of the page",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_og_tags",
+			Description: "Get the page's Open Graph (og:*) properties as a map",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_network_requests",
+			Description: "Record XHR/fetch calls made by the page for a period of time and return them",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":      {Type: "integer", Description: "ID of the tab"},
+					"durationMs": {Type: "integer", Description: "How long to record in milliseconds (default: 5000, max: 25000)"},
+					"urlPattern": {Type: "string", Description: "Only return requests whose URL contains this string"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_tab_mute",
+			Description: "Mute a tab",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab to mute"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_tab_unmute",
+			Description: "Unmute a tab",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab to unmute"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_pdf",
+			Description: "Export a tab's page as a base64-encoded PDF (Chromium only)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":           {Type: "integer", Description: "ID of the tab"},
+					"landscape":       {Type: "boolean", Description: "Use landscape orientation"},
+					"printBackground": {Type: "boolean", Description: "Include background graphics"},
+					"paperWidth":      {Type: "number", Description: "Paper width in inches"},
+					"paperHeight":     {Type: "number", Description: "Paper height in inches"},
+					"marginTop":       {Type: "number", Description: "Top margin in inches"},
+					"marginBottom":    {Type: "number", Description: "Bottom margin in inches"},
+					"marginLeft":      {Type: "number", Description: "Left margin in inches"},
+					"marginRight":     {Type: "number", Description: "Right margin in inches"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_iframe_execute_script",
+			Description: "Execute JavaScript inside a same-origin iframe",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":         {Type: "integer", Description: "ID of the tab"},
+					"frameSelector": {Type: "string", Description: "Selector of the iframe element"},
+					"script":        {Type: "string", Description: "JavaScript code"},
+				},
+				Required: []string{"tabId", "frameSelector", "script"},
+			},
+		},
+		{
+			Name:        "browser_page_highlight_elements",
+			Description: "Temporarily outline elements matching a selector, for visual debugging; returns the match count",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":      {Type: "integer", Description: "ID of the tab"},
+					"selector":   {Type: "string", Description: selectorDescription},
+					"color":      {Type: "string", Description: "CSS outline color (default: red)"},
+					"durationMs": {Type: "integer", Description: "How long to keep the highlight in milliseconds (default: 2000)"},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_get_element_by_label",
+			Description: "Find the form control associated with a visible <label> text",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"labelText": {Type: "string", Description: "Label text (exact match preferred, otherwise case-insensitive substring)"},
+				},
+				Required: []string{"tabId", "labelText"},
+			},
+		},
+		{
+			Name:        "browser_page_fill_by_label",
+			Description: "Fill the form control associated with a visible <label> text",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"labelText": {Type: "string", Description: "Label text (exact match preferred, otherwise case-insensitive substring)"},
+					"value":     {Type: "string", Description: "Value to fill"},
+				},
+				Required: []string{"tabId", "labelText", "value"},
+			},
+		},
+		{
+			Name:        "browser_page_emulate_geolocation",
+			Description: "Override the position reported by navigator.geolocation in a tab; persists across navigations",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"latitude":  {Type: "number", Description: "Latitude in degrees"},
+					"longitude": {Type: "number", Description: "Longitude in degrees"},
+					"accuracy":  {Type: "number", Description: "Accuracy in meters (default: 10)"},
+				},
+				Required: []string{"tabId", "latitude", "longitude"},
+			},
+		},
+		{
+			Name:        "browser_page_clear_geolocation",
+			Description: "Remove a tab's geolocation override",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_emulate_device",
+			Description: "Override the user agent, viewport size and pixel ratio reported to page scripts (layout is not resized)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":            {Type: "integer", Description: "ID of the tab"},
+					"userAgent":        {Type: "string", Description: "navigator.userAgent value"},
+					"width":            {Type: "integer", Description: "Viewport width in CSS pixels"},
+					"height":           {Type: "integer", Description: "Viewport height in CSS pixels"},
+					"devicePixelRatio": {Type: "number", Description: "window.devicePixelRatio value"},
+					"mobile":           {Type: "boolean", Description: "Report touch support"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_emulate_device_preset",
+			Description: "Emulate a named device profile",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":  {Type: "integer", Description: "ID of the tab"},
+					"device": {Type: "string", Description: "Device name: iPhone 14, iPhone SE, iPad, Pixel 7, Galaxy S21 or Desktop 1080p"},
+				},
+				Required: []string{"tabId", "device"},
+			},
+		},
+		{
+			Name:        "browser_page_get_page_errors",
+			Description: "Get uncaught JavaScript errors and unhandled promise rejections captured since the page loaded",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":          {Type: "integer", Description: "ID of the tab"},
+					"clearAfterRead": {Type: "boolean", Description: "Clear the captured errors after returning them"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_intercept_response",
+			Description: "Wait for the page to complete an XHR/fetch call matching a URL pattern and return its response",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":      {Type: "integer", Description: "ID of the tab"},
+					"urlPattern": {Type: "string", Description: "Only match requests whose URL contains this string"},
+					"timeoutMs":  {Type: "integer", Description: "Maximum time to wait in milliseconds (default: 10000, max: 25000)"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_title",
+			Description: "Get the page title (much cheaper than browser_page_content)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_url",
+			Description: "Get the page's current URL (much cheaper than browser_page_content)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_content_markdown",
+			Description: "Get page content converted to Markdown",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_extension_info",
+			Description: "Get the connected extension's version, browser and supported methods",
+			InputSchema: Parameters{Type: "object", Properties: map[string]Property{}, Required: []string{}},
+		},
+		{
+			Name:        "browser_page_run_snippet",
+			Description: "Run a predefined named script (e.g. extract_tables, form_values) in the page",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+					"name":  {Type: "string", Description: "Snippet name"},
+					"args":  {Type: "object", Description: "Arguments interpolated into the snippet"},
+				},
+				Required: []string{"tabId", "name"},
+			},
+		},
+		{
+			Name:        "browser_tabs_filter",
+			Description: "List tabs matching all of the given criteria",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"urlPattern":    {Type: "string", Description: "Glob pattern matched against the tab URL (path.Match syntax)"},
+					"titleContains": {Type: "string", Description: "Case-insensitive substring of the tab title"},
+					"status":        {Type: "string", Description: "Tab status: loading or complete"},
+					"active":        {Type: "boolean", Description: "Only active (or inactive) tabs"},
+					"pinned":        {Type: "boolean", Description: "Only pinned (or unpinned) tabs"},
+					"audible":       {Type: "boolean", Description: "Only audible (or silent) tabs"},
+				},
+			},
+		},
+		{
+			Name:        "browser_page_get_structured_data",
+			Description: "Extract JSON-LD and microdata structured data from the page",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_screenshot_element",
+			Description: "Screenshot a single element (scrolled into view and cropped from the visible tab)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+					"format":   {Type: "string", Description: "Image format: png (default) or jpeg"},
+					"quality":  {Type: "integer", Description: "JPEG quality 1-100"},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_emulate_timezone",
+			Description: "Override the timezone used by Intl.DateTimeFormat and Date.getTimezoneOffset (lasts until navigation)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"timezone": {Type: "string", Description: "IANA timezone name (e.g., America/New_York)"},
+				},
+				Required: []string{"tabId", "timezone"},
+			},
+		},
+		{
+			Name:        "browser_page_clear_input",
+			Description: "Clear an input, textarea or contenteditable element, firing input and change events",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_get_page_source",
+			Description: "Get the page's original HTML source as served by the network, not the current DOM state (use browser_page_content for the live DOM)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_wait_for_url",
+			Description: "Wait until a tab's URL matches a glob pattern and return the URL",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"pattern":   {Type: "string", Description: "Glob pattern the whole URL must match; * matches any characters including / (e.g., https://example.com/* matches https://example.com/a/b?q=1)"},
+					"timeoutMs": {Type: "integer", Description: "Maximum time to wait in milliseconds (default and max: 25000)"},
+				},
+				Required: []string{"tabId", "pattern"},
+			},
+		},
+		{
+			Name:        "browser_page_list_storage_keys",
+			Description: "List all keys in the page's localStorage or sessionStorage",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":       {Type: "integer", Description: "ID of the tab"},
+					"storageType": {Type: "string", Description: "Storage to read: local (default) or session"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_clear_storage",
+			Description: "Remove all entries from the page's localStorage or sessionStorage",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":       {Type: "integer", Description: "ID of the tab"},
+					"storageType": {Type: "string", Description: "Storage to clear: local (default) or session"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_tab_reload",
+			Description: "Reload a tab; returns immediately, use browser_page_wait_for_navigation to wait for the load",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":       {Type: "integer", Description: "ID of the tab"},
+					"bypassCache": {Type: "boolean", Description: "Ignore the cache, like a hard reload (default: false)"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_selection",
+			Description: "Get the text currently selected in the page",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_set_selection",
+			Description: "Select a range of characters within an element's text",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+					"start":    {Type: "integer", Description: "Offset of the first selected character"},
+					"end":      {Type: "integer", Description: "Offset after the last selected character"},
+				},
+				Required: []string{"tabId", "selector", "start", "end"},
+			},
+		},
+		{
+			Name:        "browser_page_click_at_coordinates",
+			Description: "Click the element at viewport coordinates (for canvases and image maps)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":  {Type: "integer", Description: "ID of the tab"},
+					"x":      {Type: "integer", Description: "X coordinate in CSS pixels from the viewport's left edge"},
+					"y":      {Type: "integer", Description: "Y coordinate in CSS pixels from the viewport's top edge"},
+					"button": {Type: "string", Description: "Mouse button: left (default), right or middle"},
+				},
+				Required: []string{"tabId", "x", "y"},
+			},
+		},
+		{
+			Name:        "browser_page_get_element_text",
+			Description: "Get the visible text (innerText) of a single element",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_get_element_html",
+			Description: "Get the innerHTML of a single element",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_wait_for_text",
+			Description: "Wait until text appears on (or disappears from) the page",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"text":      {Type: "string", Description: "Text to look for in the page's visible text"},
+					"present":   {Type: "boolean", Description: "Wait for the text to appear (default: true) or, if false, to disappear"},
+					"timeoutMs": {Type: "integer", Description: "Maximum time to wait in milliseconds (default and max: 25000)"},
+				},
+				Required: []string{"tabId", "text"},
+			},
+		},
+		{
+			Name:        "browser_page_get_scroll_position",
+			Description: "Get the page's current scroll offset (x, y)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_scroll_size",
+			Description: "Get the page's full scrollable width and height",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_emulate_media",
+			Description: "Emulate the print or screen CSS media type, or reset it",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+					"media": {Type: "string", Description: "Media type: print, screen, or empty to reset"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_count_elements",
+			Description: "Count the elements matching a selector (cheaper than browser_page_find)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_element_exists",
+			Description: "Check whether any element matches a selector",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_get_form_fields",
+			Description: "List a form's fields with their names, types, selectors and current values",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":        {Type: "integer", Description: "ID of the tab"},
+					"formSelector": {Type: "string", Description: "Selector of the form element"},
+				},
+				Required: []string{"tabId", "formSelector"},
+			},
+		},
+		{
+			Name:        "browser_page_scroll_by",
+			Description: "Scroll the page relative to its current position",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"deltaX":   {Type: "integer", Description: "Pixels to scroll right (negative scrolls left)"},
+					"deltaY":   {Type: "integer", Description: "Pixels to scroll down (negative scrolls up)"},
+					"behavior": {Type: "string", Description: "Scroll behavior: auto (default) or smooth"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_scroll_element_by",
+			Description: "Scroll a scrollable element relative to its current position",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+					"deltaX":   {Type: "integer", Description: "Pixels to scroll right (negative scrolls left)"},
+					"deltaY":   {Type: "integer", Description: "Pixels to scroll down (negative scrolls up)"},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_get_links",
+			Description: "Get the page's links, optionally filtered, without the rest of the page content",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":        {Type: "integer", Description: "ID of the tab"},
+					"urlPattern":   {Type: "string", Description: "Glob pattern the link URL must match"},
+					"textContains": {Type: "string", Description: "Case-insensitive substring of the link text"},
+					"limit":        {Type: "integer", Description: "Maximum number of links to return"},
+					"baseURLOnly":  {Type: "boolean", Description: "Strip query strings and fragments before removing duplicates"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_images",
+			Description: "List the page's images with their sizes and load state",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":         {Type: "integer", Description: "ID of the tab"},
+					"loadedOnly":    {Type: "boolean", Description: "Only include images that loaded successfully"},
+					"includeBase64": {Type: "boolean", Description: "Embed images under 100 KB as data URLs (default: false)"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_trigger_event",
+			Description: "Dispatch a DOM event (mouse, keyboard, input, focus, custom or plain) on an element, window or document",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"selector":  {Type: "string", Description: selectorDescription + ", or window or document"},
+					"eventType": {Type: "string", Description: "Event type (e.g., input, change, keydown, resize, my-custom-event)"},
+					"eventInit": {Type: "object", Description: "Event constructor options (e.g., {\"key\": \"Enter\"} or {\"detail\": {...}})"},
+				},
+				Required: []string{"tabId", "selector", "eventType"},
+			},
+		},
+		{
+			Name:        "browser_page_get_table_data",
+			Description: "Extract a table as JSON headers and rows, flattening rowspan, colspan and multi-row headers",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_get_dropdown_options",
+			Description: "List the options of a select element",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_get_selected_option",
+			Description: "Get the currently selected option of a select element",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_tab_move",
+			Description: "Move a tab to another position or window without reloading it",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"windowId": {Type: "integer", Description: "Target window ID (omit to stay in the current window)"},
+					"index":    {Type: "integer", Description: "Position in the window's tab strip (-1 for the end)"},
+				},
+				Required: []string{"tabId", "index"},
+			},
+		},
+		{
+			Name:        "browser_tab_move_to_new_window",
+			Description: "Move a tab into a new window of its own without reloading it",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_tab_focus_and_navigate",
+			Description: "Activate a tab, navigate it to a URL and optionally wait for the page to load, in one call",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":       {Type: "integer", Description: "ID of the tab"},
+					"url":         {Type: "string", Description: "URL to navigate to"},
+					"waitForLoad": {Type: "boolean", Description: "Wait up to 10 seconds for the page to finish loading"},
+				},
+				Required: []string{"tabId", "url"},
+			},
+		},
+		{
+			Name:        "browser_page_get_video_info",
+			Description: "Get the playback state of a video element",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_control_video",
+			Description: "Play, pause, mute or unmute a video element",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+					"action":   {Type: "string", Description: "Action: play, pause, mute or unmute"},
+				},
+				Required: []string{"tabId", "selector", "action"},
+			},
+		},
+		{
+			Name:        "browser_page_execute_async",
+			Description: "Execute asynchronous JavaScript in the page; the script calls arguments[arguments.length-1](result) to return",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"script":    {Type: "string", Description: "Function body to run; call arguments[arguments.length-1](result) when done"},
+					"timeoutMs": {Type: "integer", Description: "Maximum time to wait for the callback in milliseconds (default: 5000, max: 25000)"},
+				},
+				Required: []string{"tabId", "script"},
+			},
+		},
+		{
+			Name:        "browser_page_get_performance_metrics",
+			Description: "Get page load timings (TTFB, DOMContentLoaded, load, FCP, LCP) in milliseconds",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_right_click",
+			Description: "Right-click an element, dispatching a contextmenu event",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_double_click",
+			Description: "Double-click an element (two click events followed by dblclick)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_get_canvas_data",
+			Description: "Read the contents of a canvas element as an image data URL",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":    {Type: "integer", Description: "ID of the tab"},
+					"selector": {Type: "string", Description: selectorDescription},
+					"format":   {Type: "string", Description: "Image format: png (default) or jpeg"},
+				},
+				Required: []string{"tabId", "selector"},
+			},
+		},
+		{
+			Name:        "browser_page_emulate_dark_mode",
+			Description: "Emulate the prefers-color-scheme media feature (dark or light), or reset it",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":  {Type: "integer", Description: "ID of the tab"},
+					"scheme": {Type: "string", Description: "Color scheme: dark, light, or empty to reset"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_tabs_close_multiple",
+			Description: "Close several tabs in one request",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabIds": {
+						Type:        "array",
+						Description: "IDs of the tabs to close",
+						Items:       &Property{Type: "integer"},
+					},
+				},
+				Required: []string{"tabIds"},
+			},
+		},
+		{
+			Name:        "browser_tabs_close_others",
+			Description: "Close every tab except the given one",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab to keep open"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_anchor_by_text",
+			Description: "Find the first link whose visible text matches",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+					"text":  {Type: "string", Description: "Link text to look for"},
+					"exact": {Type: "boolean", Description: "Require the whole trimmed text to match instead of a substring (default false)"},
+				},
+				Required: []string{"tabId", "text"},
+			},
+		},
+		{
+			Name:        "browser_page_click_anchor_by_text",
+			Description: "Click the first link whose visible text matches",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+					"text":  {Type: "string", Description: "Link text to look for"},
+					"exact": {Type: "boolean", Description: "Require the whole trimmed text to match instead of a substring (default false)"},
+				},
+				Required: []string{"tabId", "text"},
+			},
+		},
+		{
+			Name:        "browser_page_set_attribute",
+			Description: "Set an attribute on an element, such as disabled, href, data-* or aria-*",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"selector":  {Type: "string", Description: selectorDescription},
+					"attribute": {Type: "string", Description: "Attribute name"},
+					"value":     {Type: "string", Description: "Attribute value (empty for boolean attributes)"},
+				},
+				Required: []string{"tabId", "selector", "attribute"},
+			},
+		},
+		{
+			Name:        "browser_page_remove_attribute",
+			Description: "Remove an attribute from an element",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"selector":  {Type: "string", Description: selectorDescription},
+					"attribute": {Type: "string", Description: "Attribute name"},
+				},
+				Required: []string{"tabId", "selector", "attribute"},
+			},
+		},
+		{
+			Name:        "browser_page_get_all_text_nodes",
+			Description: "Get the raw text nodes of the page, without markup or attribute values",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"minLength": {Type: "integer", Description: "Minimum number of non-whitespace characters for a text node to be included (default 1)"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_visible_text",
+			Description: "Get the text of rendered text nodes only, one per line",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_run_lighthouse",
+			Description: "Audit the page and return Lighthouse-style category scores (0-100) with per-audit diagnostics. Covers the Lighthouse audits computable from the loaded page; performance is scored from paint and layout-shift timings only",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+					"categories": {
+						Type:        "array",
+						Description: "Categories to audit: performance, accessibility, best-practices, seo (default all)",
+						Items:       &Property{Type: "string"},
+					},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_color_at",
+			Description: "Sample the rendered color of the pixel at viewport coordinates",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+					"x":     {Type: "integer", Description: "X coordinate in CSS pixels from the viewport's left edge"},
+					"y":     {Type: "integer", Description: "Y coordinate in CSS pixels from the viewport's top edge"},
+				},
+				Required: []string{"tabId", "x", "y"},
+			},
+		},
+		{
+			Name:        "browser_page_get_shadow_dom",
+			Description: "Get the HTML and text content of an element's shadow root",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":        {Type: "integer", Description: "ID of the tab"},
+					"hostSelector": {Type: "string", Description: "Shadow host element; " + selectorDescription},
+				},
+				Required: []string{"tabId", "hostSelector"},
+			},
+		},
+		{
+			Name:        "browser_page_query_shadow_dom",
+			Description: "Find elements inside an element's shadow root",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":         {Type: "integer", Description: "ID of the tab"},
+					"hostSelector":  {Type: "string", Description: "Shadow host element; " + selectorDescription},
+					"innerSelector": {Type: "string", Description: "CSS selector evaluated inside the shadow root"},
+				},
+				Required: []string{"tabId", "hostSelector", "innerSelector"},
+			},
+		},
+		{
+			Name:        "browser_page_mock_geolocation_track",
+			Description: "Simulate a moving position: navigator.geolocation reports each position in turn, looping; persists across navigations until cleared",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+					"positions": {
+						Type:        "array",
+						Description: "Positions: {latitude, longitude, accuracy?, altitude?} with accuracy in meters (default: 10)",
+						Items:       &Property{Type: "object"},
+					},
+					"intervalMs": {Type: "integer", Description: "Milliseconds between position updates (default: 1000)"},
+				},
+				Required: []string{"tabId", "positions"},
+			},
+		},
+		{
+			Name:        "browser_page_execute_in_worker",
+			Description: "Run JavaScript in a Web Worker created in the page. The script is the body of an async function; its return value is the result",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"script":    {Type: "string", Description: "JavaScript to run in the worker (at most 1 MB)"},
+					"timeoutMs": {Type: "integer", Description: "Maximum time to wait for the result in milliseconds (default 5000, max 25000)"},
+				},
+				Required: []string{"tabId", "script"},
+			},
+		},
+		{
+			Name:        "browser_page_get_robots_txt",
+			Description: "Get the robots.txt of the current page's origin",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_check_robots_allowed",
+			Description: "Check whether the origin's robots.txt allows a user agent to crawl a path",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":     {Type: "integer", Description: "ID of the tab"},
+					"path":      {Type: "string", Description: "URL path to check, e.g. /private/page (default /)"},
+					"userAgent": {Type: "string", Description: "User agent product token, e.g. Googlebot (default *)"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_sitemap",
+			Description: "Get the URLs listed in the origin's /sitemap.xml, following sitemap index files",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_diff_screenshots",
+			Description: "Compare the visible tab with a baseline screenshot; returns the percentage of differing pixels and a PNG with them painted red",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":          {Type: "integer", Description: "ID of the tab"},
+					"baselineBase64": {Type: "string", Description: "Baseline PNG or JPEG, as a data URL or base64"},
+					"threshold":      {Type: "number", Description: "Fraction of differing pixels above which the page counts as changed, e.g. 0.01 for 1% (default 0)"},
+				},
+				Required: []string{"tabId", "baselineBase64"},
+			},
+		},
+		{
+			Name:        "browser_page_get_web_workers",
+			Description: "List the running Web Workers and service workers of the page's origin (Chromium only)",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_response_headers",
+			Description: "Get the HTTP response headers of the document loaded in a tab, e.g. CSP, caching and CORS policies",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_wait_for_network_idle",
+			Description: "Wait until the page has no fetch or XHR requests in flight for a period of time",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId":      {Type: "integer", Description: "ID of the tab"},
+					"idleTimeMs": {Type: "integer", Description: "How long the network must stay idle in milliseconds (default 500)"},
+					"timeoutMs":  {Type: "integer", Description: "Maximum time to wait in milliseconds (default 10000, max 25000)"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
+		{
+			Name:        "browser_page_get_storage_quota",
+			Description: "Get the storage usage and quota of the page's origin, with a per-type breakdown where available",
+			InputSchema: Parameters{
+				Type: "object",
+				Properties: map[string]Property{
+					"tabId": {Type: "integer", Description: "ID of the tab"},
+				},
+				Required: []string{"tabId"},
+			},
+		},
 	}
 }
@@ -0,0 +1,36 @@
+// Package server provides shared-secret authentication for all endpoints.
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// authorized reports whether the request carries the configured token,
+// either as "Authorization: Bearer <token>" or as a "token" query parameter.
+// Requests are always authorized when no token is configured.
+func (s *Server) authorized(r *http.Request) bool {
+	if len(s.token) == 0 {
+		return true
+	}
+
+	provided := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		provided = strings.TrimPrefix(auth, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(provided), s.token) == 1
+}
+
+// authMiddleware rejects unauthenticated requests with 401 Unauthorized.
+// The tool listing is exempt so clients can discover that auth is required.
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mcp/tools" && !s.authorized(r) {
+			s.logger.Warn("unauthorized request", "path", r.URL.Path, "remote", r.RemoteAddr)
+			http.Error(w, `{"error": "Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
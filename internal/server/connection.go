@@ -0,0 +1,52 @@
+// Package server provides notification of extension connection events.
+package server
+
+import "time"
+
+// ConnectionObserver is notified when the extension connects to or
+// disconnects from the WebSocket endpoint. Methods are called synchronously
+// from the connection handler and should return quickly.
+type ConnectionObserver interface {
+	OnConnect(sessionID string)
+	// OnDisconnect receives the same reason that is logged on disconnect:
+	// clean_close, keepalive_timeout or error.
+	OnDisconnect(sessionID string, reason string)
+}
+
+// WithConnectionObserver notifies o of extension connects and disconnects.
+func WithConnectionObserver(o ConnectionObserver) Option {
+	return func(s *Server) {
+		s.connObserver = o
+	}
+}
+
+// ConnectionEvent describes an extension connect or disconnect.
+type ConnectionEvent struct {
+	Type      string // "connect" or "disconnect"
+	SessionID string
+	Reason    string // empty for connect events
+	Time      time.Time
+}
+
+// ChannelConnectionObserver sends connection events to a channel. Events are
+// dropped rather than blocking the server when the channel is full.
+type ChannelConnectionObserver struct {
+	Events chan<- ConnectionEvent
+}
+
+// OnConnect implements ConnectionObserver.
+func (o ChannelConnectionObserver) OnConnect(sessionID string) {
+	o.send(ConnectionEvent{Type: "connect", SessionID: sessionID, Time: time.Now()})
+}
+
+// OnDisconnect implements ConnectionObserver.
+func (o ChannelConnectionObserver) OnDisconnect(sessionID string, reason string) {
+	o.send(ConnectionEvent{Type: "disconnect", SessionID: sessionID, Reason: reason, Time: time.Now()})
+}
+
+func (o ChannelConnectionObserver) send(event ConnectionEvent) {
+	select {
+	case o.Events <- event:
+	default:
+	}
+}
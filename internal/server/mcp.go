@@ -2,41 +2,60 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/logging"
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
 )
 
 // setupMCPRoutes adds MCP protocol endpoints to the mux.
 func (s *Server) setupMCPRoutes(mux *http.ServeMux) {
-	// MCP 2024-11-05 protocol endpoints
+	// MCP protocol endpoints
 	mux.HandleFunc("/mcp/info", s.handleMCPInfo)
 	mux.HandleFunc("/mcp/tools", s.handleMCPTools)
 	mux.HandleFunc("/mcp/call/", s.handleMCPCall)
+	s.registerTools()
 	
 	// Direct tab endpoints
 	mux.HandleFunc("/tabs", s.handleTabs)
 	mux.HandleFunc("/tabs/", s.handleTabActions)
+	
+	// Bookmark endpoints
+	mux.HandleFunc("/bookmarks", s.handleBookmarks)
+	mux.HandleFunc("/bookmarks/", s.handleBookmarks)
 }
 
 func (s *Server) handleMCPInfo(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]any{
-		"name":                "browser-mcp",
-		"version":             "1.0.0",
-		"protocol_version":    "2024-11-05",
-		"tools":               s.handler.GetTools(),
-		"extension_connected": s.IsConnected(),
+		"name":                        "browser-mcp",
+		"version":                     "1.0.0",
+		"protocol_version":            s.latestProtocolVersion(),
+		"supported_protocol_versions": s.sortedProtocolVersions(),
+		"tools":                       s.toolList(),
+		"extension_connected":         s.IsConnected(),
 	})
 }
 
 func (s *Server) handleMCPTools(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
+	if !s.authorized(r) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"tools":         []any{},
+			"auth_required": true,
+		})
+		return
+	}
 	json.NewEncoder(w).Encode(map[string]any{
-		"tools": s.handler.GetTools(),
+		"tools": s.toolList(),
 	})
 }
 
@@ -54,10 +73,11 @@ func (s *Server) handleMCPCall(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := s.callTool(toolName, params)
+	ctx := s.requestContext(r)
+	result, err := s.callTool(ctx, toolName, params)
 	if err != nil {
-		s.logger.Error("tool call failed", "tool", toolName, "error", err)
-		http.Error(w, fmt.Sprintf(`{"error": "%s"}`, err.Error()), http.StatusInternalServerError)
+		logging.LoggerFromContext(ctx).Error("tool call failed", "tool", toolName, "error", err)
+		http.Error(w, fmt.Sprintf(`{"error": "%s"}`, err.Error()), errorStatus(err))
 		return
 	}
 
@@ -96,6 +116,59 @@ func (s *Server) handleTabs(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleBookmarks serves GET /bookmarks?query=..., POST /bookmarks and
+// DELETE /bookmarks/{id}.
+func (s *Server) handleBookmarks(w http.ResponseWriter, r *http.Request) {
+	if !s.IsConnected() {
+		http.Error(w, `{"error": "Extension not connected"}`, http.StatusServiceUnavailable)
+		return
+	}
+
+	ctx := r.Context()
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/bookmarks"), "/")
+
+	switch r.Method {
+	case http.MethodGet:
+		bookmarks, err := s.handler.ListBookmarks(ctx, r.URL.Query().Get("query"))
+		if err != nil {
+			s.httpError(w, err)
+			return
+		}
+		s.jsonResponse(w, map[string]any{"bookmarks": bookmarks})
+
+	case http.MethodPost:
+		var req struct {
+			ParentID string `json:"parentId"`
+			Title    string `json:"title"`
+			URL      string `json:"url"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.httpError(w, err)
+			return
+		}
+		bookmark, err := s.handler.CreateBookmark(ctx, req.ParentID, req.Title, req.URL)
+		if err != nil {
+			s.httpError(w, err)
+			return
+		}
+		s.jsonResponse(w, bookmark)
+
+	case http.MethodDelete:
+		if id == "" {
+			http.Error(w, `{"error": "Missing bookmark ID"}`, http.StatusBadRequest)
+			return
+		}
+		if err := s.handler.DeleteBookmark(ctx, id); err != nil {
+			s.httpError(w, err)
+			return
+		}
+		s.jsonResponse(w, map[string]any{"success": true})
+
+	default:
+		http.Error(w, `{"error": "Method not allowed"}`, http.StatusMethodNotAllowed)
+	}
+}
+
 func (s *Server) handleTabActions(w http.ResponseWriter, r *http.Request) {
 	if !s.IsConnected() {
 		http.Error(w, `{"error": "Extension not connected"}`, http.StatusServiceUnavailable)
@@ -136,6 +209,30 @@ func (s *Server) handleTabActions(w http.ResponseWriter, r *http.Request) {
 		}
 		s.jsonResponse(w, result)
 		
+	case "content_markdown":
+		markdown, err := s.handler.GetPageContentMarkdown(ctx, tabID)
+		if err != nil {
+			s.httpError(w, err)
+			return
+		}
+		s.jsonResponse(w, map[string]any{"markdown": markdown})
+		
+	case "links":
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		baseURLOnly, _ := strconv.ParseBool(query.Get("baseURLOnly"))
+		result, err := s.handler.GetLinks(ctx, tabID, mcp.LinkFilter{
+			URLPattern:   query.Get("urlPattern"),
+			TextContains: query.Get("textContains"),
+			Limit:        limit,
+			BaseURLOnly:  baseURLOnly,
+		})
+		if err != nil {
+			s.httpError(w, err)
+			return
+		}
+		s.jsonResponse(w, result)
+		
 	case "screenshot":
 		result, err := s.handler.ScreenshotTab(ctx, tabID)
 		if err != nil {
@@ -166,6 +263,14 @@ func (s *Server) handleTabActions(w http.ResponseWriter, r *http.Request) {
 		}
 		s.jsonResponse(w, map[string]any{"success": true})
 		
+	case "reload":
+		bypassCache, _ := reqBody["bypassCache"].(bool)
+		if err := s.handler.ReloadTab(ctx, tabID, bypassCache); err != nil {
+			s.httpError(w, err)
+			return
+		}
+		s.jsonResponse(w, map[string]any{"success": true})
+		
 	case "execute":
 		script, _ := reqBody["script"].(string)
 		result, err := s.handler.ExecuteScript(ctx, tabID, script)
@@ -201,6 +306,31 @@ func (s *Server) handleTabActions(w http.ResponseWriter, r *http.Request) {
 		}
 		s.jsonResponse(w, map[string]any{"success": true})
 		
+	case "scroll_to_element":
+		selector, _ := reqBody["selector"].(string)
+		behavior, _ := reqBody["behavior"].(string)
+		if err := s.handler.ScrollToElement(ctx, tabID, selector, behavior); err != nil {
+			s.httpError(w, err)
+			return
+		}
+		s.jsonResponse(w, map[string]any{"success": true})
+		
+	case "focus":
+		selector, _ := reqBody["selector"].(string)
+		if err := s.handler.FocusElement(ctx, tabID, selector); err != nil {
+			s.httpError(w, err)
+			return
+		}
+		s.jsonResponse(w, map[string]any{"success": true})
+		
+	case "blur":
+		selector, _ := reqBody["selector"].(string)
+		if err := s.handler.BlurElement(ctx, tabID, selector); err != nil {
+			s.httpError(w, err)
+			return
+		}
+		s.jsonResponse(w, map[string]any{"success": true})
+		
 	case "find":
 		selector, _ := reqBody["selector"].(string)
 		result, err := s.handler.FindElements(ctx, tabID, selector)
@@ -243,156 +373,76 @@ func makeJSONResult(data any) (map[string]any, error) {
 	return makeTextResult(string(jsonBytes)), nil
 }
 
-func (s *Server) callTool(toolName string, params json.RawMessage) (any, error) {
-	ctx := &dummyContext{}
-	
-	switch toolName {
-	case "browser_tabs_list":
-		tabs, err := s.handler.ListTabs(ctx)
-		if err != nil {
-			return nil, err
-		}
-		return makeJSONResult(tabs)
-		
-	case "browser_tab_activate":
-		var p struct{ TabID int `json:"tabId"` }
-		if err := json.Unmarshal(params, &p); err != nil {
-			return nil, err
-		}
-		if err := s.handler.ActivateTab(ctx, p.TabID); err != nil {
-			return nil, err
-		}
-		return makeTextResult(fmt.Sprintf("Tab %d activated", p.TabID)), nil
-		
-	case "browser_tab_navigate":
-		var p struct {
-			TabID int    `json:"tabId"`
-			URL   string `json:"url"`
-		}
-		s.logger.Debug("browser_tab_navigate called", "params", string(params))
-		if err := json.Unmarshal(params, &p); err != nil {
-			s.logger.Error("failed to unmarshal navigate params", "error", err, "params", string(params))
-			return nil, err
-		}
-		s.logger.Debug("parsed navigate params", "tabId", p.TabID, "url", p.URL)
-		if err := s.handler.NavigateTab(ctx, p.TabID, p.URL); err != nil {
-			return nil, err
-		}
-		return makeTextResult(fmt.Sprintf("Navigated tab %d to %s", p.TabID, p.URL)), nil
-		
-	case "browser_tab_close":
-		var p struct{ TabID int `json:"tabId"` }
-		if err := json.Unmarshal(params, &p); err != nil {
-			return nil, err
-		}
-		if err := s.handler.CloseTab(ctx, p.TabID); err != nil {
-			return nil, err
-		}
-		return makeTextResult(fmt.Sprintf("Tab %d closed", p.TabID)), nil
-		
-	case "browser_tab_screenshot":
-		var p struct{ TabID int `json:"tabId"` }
-		if err := json.Unmarshal(params, &p); err != nil {
-			return nil, err
-		}
-		dataUrl, err := s.handler.ScreenshotTab(ctx, p.TabID)
-		if err != nil {
-			return nil, err
-		}
-		return makeTextResult(dataUrl), nil
-		
-	case "browser_page_content":
-		var p struct{ TabID int `json:"tabId"` }
-		if err := json.Unmarshal(params, &p); err != nil {
-			return nil, err
-		}
-		content, err := s.handler.GetPageContent(ctx, p.TabID)
-		if err != nil {
-			return nil, err
-		}
-		return makeJSONResult(content)
-		
-	case "browser_page_click":
-		var p struct {
-			TabID    int    `json:"tabId"`
-			Selector string `json:"selector"`
-		}
-		if err := json.Unmarshal(params, &p); err != nil {
-			return nil, err
-		}
-		if err := s.handler.ClickElement(ctx, p.TabID, p.Selector); err != nil {
-			return nil, err
-		}
-		return makeTextResult(fmt.Sprintf("Clicked element: %s", p.Selector)), nil
-		
-	case "browser_page_fill":
-		var p struct {
-			TabID    int    `json:"tabId"`
-			Selector string `json:"selector"`
-			Value    string `json:"value"`
-		}
-		if err := json.Unmarshal(params, &p); err != nil {
-			return nil, err
-		}
-		if err := s.handler.FillInput(ctx, p.TabID, p.Selector, p.Value); err != nil {
-			return nil, err
-		}
-		return makeTextResult(fmt.Sprintf("Filled %s with: %s", p.Selector, p.Value)), nil
-		
-	case "browser_page_scroll":
-		var p struct {
-			TabID int `json:"tabId"`
-			X     int `json:"x"`
-			Y     int `json:"y"`
-		}
-		if err := json.Unmarshal(params, &p); err != nil {
-			return nil, err
-		}
-		if err := s.handler.ScrollPage(ctx, p.TabID, p.X, p.Y); err != nil {
-			return nil, err
-		}
-		return makeTextResult(fmt.Sprintf("Scrolled to %d, %d", p.X, p.Y)), nil
-		
-	case "browser_page_execute":
-		var p struct {
-			TabID  int    `json:"tabId"`
-			Script string `json:"script"`
-		}
-		if err := json.Unmarshal(params, &p); err != nil {
-			return nil, err
-		}
-		result, err := s.handler.ExecuteScript(ctx, p.TabID, p.Script)
-		if err != nil {
-			return nil, err
-		}
-		return makeJSONResult(result)
-		
-	case "browser_page_find":
-		var p struct {
-			TabID    int    `json:"tabId"`
-			Selector string `json:"selector"`
-		}
-		if err := json.Unmarshal(params, &p); err != nil {
-			return nil, err
-		}
-		result, err := s.handler.FindElements(ctx, p.TabID, p.Selector)
-		if err != nil {
-			return nil, err
-		}
-		return makeJSONResult(result)
-		
-	default:
-		return nil, fmt.Errorf("unknown tool: %s", toolName)
+// requestContext returns a context for handling r whose logger is tagged
+// with a correlation ID, taken from the X-Request-ID header if present, and
+// the client IP. It is cancelled when the client disconnects.
+func (s *Server) requestContext(r *http.Request) context.Context {
+	correlationID := r.Header.Get("X-Request-ID")
+	if correlationID == "" {
+		correlationID = generateSessionID()
+	}
+	logger := s.logger.With("correlation_id", correlationID)
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		logger = logger.With("client_ip", host)
+	}
+	return logging.ContextWithLogger(r.Context(), logger)
+}
+
+// callTool dispatches a tool call and records its outcome and latency.
+// ctx should carry the request's logger; see requestContext.
+func (s *Server) callTool(ctx context.Context, toolName string, params json.RawMessage) (any, error) {
+	start := time.Now()
+	result, err := s.dispatchTool(ctx, toolName, params)
+	if s.metrics != nil {
+		s.metrics.observe(s.metricsToolLabel(toolName), err, time.Since(start))
+	}
+	return result, err
+}
+
+// resolveTabParam replaces a tabId of -1 in tool params with the ID of the
+// first tab matching the tabUrl glob pattern.
+func (s *Server) resolveTabParam(ctx context.Context, params json.RawMessage) (json.RawMessage, error) {
+	var p map[string]any
+	if err := json.Unmarshal(params, &p); err != nil {
+		return params, nil
 	}
+	if id, ok := p["tabId"].(float64); !ok || id != -1 {
+		return params, nil
+	}
+
+	tabURL, _ := p["tabUrl"].(string)
+	if tabURL == "" {
+		return nil, fmt.Errorf("tabUrl is required when tabId is -1")
+	}
+	tabID, err := s.handler.ResolveTabID(ctx, tabURL)
+	if err != nil {
+		return nil, err
+	}
+	p["tabId"] = tabID
+	return json.Marshal(p)
+}
+
+// metricsToolLabel returns the tool name to use as a metrics label, folding
+// unknown names into "unknown" to keep label cardinality bounded.
+func (s *Server) metricsToolLabel(toolName string) string {
+	if _, ok := s.tools[toolName]; ok {
+		return toolName
+	}
+	return "unknown"
 }
 
-// dummyContext implements context.Context for handler calls
-type dummyContext struct{}
+func (s *Server) dispatchTool(ctx context.Context, toolName string, params json.RawMessage) (any, error) {
+	handle, ok := s.tools[toolName]
+	if !ok {
+		return nil, fmt.Errorf("unknown tool: %s", toolName)
+	}
 
-func (d *dummyContext) Deadline() (deadline time.Time, ok bool) { return time.Time{}, false }
-func (d *dummyContext) Done() <-chan struct{} { return nil }
-func (d *dummyContext) Err() error { return nil }
-func (d *dummyContext) Value(key interface{}) interface{} { return nil }
+	params, err := s.resolveTabParam(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return handle(ctx, params)
+}
 
 func (s *Server) jsonResponse(w http.ResponseWriter, data any) {
 	w.Header().Set("Content-Type", "application/json")
@@ -401,5 +451,13 @@ func (s *Server) jsonResponse(w http.ResponseWriter, data any) {
 
 func (s *Server) httpError(w http.ResponseWriter, err error) {
 	s.logger.Error("request failed", "error", err)
-	http.Error(w, fmt.Sprintf(`{"error": "%s"}`, err.Error()), http.StatusInternalServerError)
+	http.Error(w, fmt.Sprintf(`{"error": "%s"}`, err.Error()), errorStatus(err))
+}
+
+// errorStatus returns the HTTP status code for a failed request.
+func errorStatus(err error) int {
+	if errors.Is(err, ErrOverloaded) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
 }
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+func TestRequestContextCancelledWithRequest(t *testing.T) {
+	s := newTestServer()
+	parent, cancel := context.WithCancel(context.Background())
+	r := httptest.NewRequest("POST", "/mcp/tools/browser_tabs_list", nil).WithContext(parent)
+
+	ctx := s.requestContext(r)
+	cancel()
+	select {
+	case <-ctx.Done():
+	default:
+		t.Fatal("request context not cancelled with the request")
+	}
+}
+
+func TestToolListHidesUnsupportedTools(t *testing.T) {
+	s := New(toolsHandler{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.registerTools()
+	listed := func(name string) bool {
+		return slices.ContainsFunc(s.toolList(), func(tool mcp.Tool) bool { return tool.Name == name })
+	}
+
+	if !listed("browser_tabs_list") {
+		t.Fatal("browser_tabs_list not listed")
+	}
+	if listed("browser_page_run_lighthouse") {
+		t.Error("browser_page_run_lighthouse listed before the extension announced browser.lighthouse.run")
+	}
+
+	s.extensionInfo = &ExtensionInfo{SupportedMethods: []string{"browser.tabs.query"}}
+	if listed("browser_page_run_lighthouse") {
+		t.Error("browser_page_run_lighthouse listed for an extension without browser.lighthouse.run")
+	}
+
+	s.extensionInfo = &ExtensionInfo{SupportedMethods: []string{"browser.tabs.query", "browser.lighthouse.run"}}
+	if !listed("browser_page_run_lighthouse") {
+		t.Error("browser_page_run_lighthouse not listed for an extension with browser.lighthouse.run")
+	}
+}
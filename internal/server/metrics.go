@@ -0,0 +1,117 @@
+// Package server provides a Prometheus-compatible metrics endpoint.
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// durationBuckets are the upper bounds, in seconds, of the tool call
+// latency histogram.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+type callKey struct {
+	tool   string
+	status string
+}
+
+type histogram struct {
+	counts []uint64 // per bucket, non-cumulative
+	sum    float64
+	count  uint64
+}
+
+// metrics records per-tool call counts and latencies.
+type metrics struct {
+	mu        sync.Mutex
+	calls     map[callKey]uint64
+	durations map[string]*histogram
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		calls:     make(map[callKey]uint64),
+		durations: make(map[string]*histogram),
+	}
+}
+
+// observe records a single tool call.
+func (m *metrics) observe(tool string, err error, d time.Duration) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	seconds := d.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls[callKey{tool, status}]++
+	h, ok := m.durations[tool]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(durationBuckets))}
+		m.durations[tool] = h
+	}
+	for i, le := range durationBuckets {
+		if seconds <= le {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// writeTo renders all metrics in the Prometheus text exposition format.
+func (m *metrics) writeTo(b *strings.Builder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]callKey, 0, len(m.calls))
+	for k := range m.calls {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].tool != keys[j].tool {
+			return keys[i].tool < keys[j].tool
+		}
+		return keys[i].status < keys[j].status
+	})
+
+	b.WriteString("# HELP browser_mcp_tool_calls_total Total number of MCP tool calls.\n")
+	b.WriteString("# TYPE browser_mcp_tool_calls_total counter\n")
+	for _, k := range keys {
+		fmt.Fprintf(b, "browser_mcp_tool_calls_total{tool=%q,status=%q} %d\n", k.tool, k.status, m.calls[k])
+	}
+
+	tools := make([]string, 0, len(m.durations))
+	for t := range m.durations {
+		tools = append(tools, t)
+	}
+	sort.Strings(tools)
+
+	b.WriteString("# HELP browser_mcp_tool_duration_seconds MCP tool call latency in seconds.\n")
+	b.WriteString("# TYPE browser_mcp_tool_duration_seconds histogram\n")
+	for _, t := range tools {
+		h := m.durations[t]
+		var cumulative uint64
+		for i, le := range durationBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(b, "browser_mcp_tool_duration_seconds_bucket{tool=%q,le=\"%g\"} %d\n", t, le, cumulative)
+		}
+		fmt.Fprintf(b, "browser_mcp_tool_duration_seconds_bucket{tool=%q,le=\"+Inf\"} %d\n", t, h.count)
+		fmt.Fprintf(b, "browser_mcp_tool_duration_seconds_sum{tool=%q} %g\n", t, h.sum)
+		fmt.Fprintf(b, "browser_mcp_tool_duration_seconds_count{tool=%q} %d\n", t, h.count)
+	}
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+	s.metrics.writeTo(&b)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write([]byte(b.String()))
+}
@@ -0,0 +1,87 @@
+// Package server provides dispatch of JSON-RPC notifications sent by the extension.
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// NotificationHandler receives JSON-RPC notifications, i.e. messages without
+// an id, pushed by the extension.
+type NotificationHandler interface {
+	HandleNotification(method string, params json.RawMessage)
+}
+
+// WithNotificationHandler dispatches extension notifications to h.
+func WithNotificationHandler(h NotificationHandler) Option {
+	return func(s *Server) {
+		s.notifications = h
+	}
+}
+
+// LoggingNotificationHandler logs every notification at debug level.
+type LoggingNotificationHandler struct {
+	Logger *slog.Logger
+}
+
+// HandleNotification implements NotificationHandler.
+func (h LoggingNotificationHandler) HandleNotification(method string, params json.RawMessage) {
+	h.Logger.Debug("extension notification", "method", method, "params", string(params))
+}
+
+// handleNotification processes a notification from the extension. No
+// response is sent.
+func (s *Server) handleNotification(msg *mcp.Message) {
+	switch msg.Method {
+	case "extension/error":
+		s.logExtensionError(msg.Params)
+	case "extension/hello":
+		s.handleHello(msg.Params)
+	case "tab/removed", "debugger/detached":
+		s.forgetEmulations(msg.Method, msg.Params)
+	}
+	if s.notifications != nil {
+		s.notifications.HandleNotification(msg.Method, msg.Params)
+	}
+}
+
+// forgetEmulations drops the emulations recorded for a tab that was closed
+// or whose debugger session, carrying the media and color scheme
+// emulations, was detached.
+func (s *Server) forgetEmulations(method string, raw json.RawMessage) {
+	var params struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		s.logger.Error("invalid "+method, "error", err)
+		return
+	}
+	if s.handler == nil {
+		return
+	}
+	if method == "debugger/detached" {
+		s.handler.ClearEmulations(params.TabID, "media", "colorScheme")
+	} else {
+		s.handler.ClearEmulations(params.TabID)
+	}
+}
+
+// logExtensionError logs an error reported by the extension.
+func (s *Server) logExtensionError(raw json.RawMessage) error {
+	var params struct {
+		Message string `json:"message"`
+		Stack   string `json:"stack"`
+		Context string `json:"context"`
+		Time    int64  `json:"time"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return err
+	}
+	s.logger.Error("extension error",
+		"context", params.Context,
+		"message", params.Message,
+		"stack", params.Stack)
+	return nil
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// emulationHandler is a Handler that records the emulations it is asked to
+// clear; calling any other method panics.
+type emulationHandler struct {
+	Handler
+	mu      sync.Mutex
+	cleared []string
+}
+
+func (h *emulationHandler) ClearEmulations(tabID int, kinds ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cleared = append(h.cleared, fmt.Sprint(tabID, kinds))
+}
+
+func (h *emulationHandler) ClearAllEmulations() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cleared = append(h.cleared, "all")
+}
+
+func (h *emulationHandler) calls() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.cleared...)
+}
+
+func TestEmulationsForgotten(t *testing.T) {
+	h := &emulationHandler{}
+	s := New(h, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	conn := connectExtension(t, s, echoMethod)
+
+	// Notifications are handled concurrently, so send them one at a time
+	for i, msg := range []string{
+		`{"method": "debugger/detached", "params": {"tabId": 3}}`,
+		`{"method": "tab/removed", "params": {"tabId": 4}}`,
+	} {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		waitFor(t, func() bool { return len(h.calls()) == i+1 })
+	}
+
+	conn.Close()
+	waitFor(t, func() bool { return len(h.calls()) == 3 })
+
+	want := []string{"3 [media colorScheme]", "4 []", "all"}
+	if got := h.calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("cleared %q, want %q", got, want)
+	}
+}
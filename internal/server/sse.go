@@ -2,6 +2,7 @@
 package server
 
 import (
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -22,13 +23,13 @@ type SSESession struct {
 var (
 	sseSessions   = make(map[string]*SSESession)
 	sseSessionsMu sync.RWMutex
-	sseCounter    int
 )
 
 // setupSSERoutes adds SSE MCP endpoints to the mux.
 func (s *Server) setupSSERoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/sse", s.handleSSE)
 	mux.HandleFunc("/message", s.handleSSEMessage)
+	mux.HandleFunc("/sse/stream/screenshot", s.handleScreenshotStream)
 }
 
 // handleSSE handles Server-Sent Events connections.
@@ -40,8 +41,7 @@ func (s *Server) handleSSE(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 
 	// Create session
-	sseCounter++
-	sessionID := fmt.Sprintf("session-%d", sseCounter)
+	sessionID := generateSessionID()
 	session := &SSESession{
 		ID:        sessionID,
 		Events:    make(chan string, 100),
@@ -90,6 +90,87 @@ func (s *Server) handleSSE(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// minScreenshotInterval respects the browser's limit of two visible-tab
+// captures per second.
+const minScreenshotInterval = 500 * time.Millisecond
+
+// handleScreenshotStream captures a tab every intervalMs milliseconds and
+// streams each frame as an SSE data event until maxFrames frames have been
+// sent (0 means no limit), a capture fails or the client disconnects.
+func (s *Server) handleScreenshotStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.IsConnected() {
+		http.Error(w, `{"error": "Extension not connected"}`, http.StatusServiceUnavailable)
+		return
+	}
+
+	var req struct {
+		TabID      int `json:"tabId"`
+		IntervalMs int `json:"intervalMs"`
+		MaxFrames  int `json:"maxFrames"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error": "Invalid JSON: %s"}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+	interval := max(time.Duration(req.IntervalMs)*time.Millisecond, minScreenshotInterval)
+
+	// The stream may outlive the server's WriteTimeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		http.Error(w, `{"error": "Streaming not supported"}`, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ctx := r.Context()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for frame := 1; ; frame++ {
+		dataURL, err := s.handler.ScreenshotTab(ctx, req.TabID)
+		if err != nil {
+			data, _ := json.Marshal(map[string]any{"frame": frame, "error": err.Error()})
+			if _, err := fmt.Fprintf(w, "event: error\ndata: %s\n\n", data); err == nil {
+				rc.Flush()
+			}
+			return
+		}
+		data, _ := json.Marshal(map[string]any{"frame": frame, "dataUrl": dataURL})
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			s.logger.Debug("screenshot stream closed", "error", err)
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			s.logger.Debug("screenshot stream closed", "error", err)
+			return
+		}
+		if frame == req.MaxFrames {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// generateSessionID returns a random UUID v4 string.
+func generateSessionID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
 // handleSSEMessage handles messages from SSE clients.
 func (s *Server) handleSSEMessage(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
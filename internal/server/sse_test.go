@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// screenshotHandler is a Handler whose only implemented method is
+// ScreenshotTab, plus the ClearAllEmulations called on disconnect; calling
+// any other method panics.
+type screenshotHandler struct {
+	Handler
+}
+
+func (screenshotHandler) ScreenshotTab(ctx context.Context, tabID int) (string, error) {
+	return "data:image/png;base64,AAAA", nil
+}
+
+func (screenshotHandler) ClearAllEmulations() {}
+
+func TestScreenshotStreamOutlivesWriteTimeout(t *testing.T) {
+	s := New(screenshotHandler{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	connectExtension(t, s, echoMethod)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(s.handleScreenshotStream))
+	ts.Config.WriteTimeout = 200 * time.Millisecond
+	ts.Start()
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL, "application/json", strings.NewReader(`{"tabId": 1, "maxFrames": 3}`))
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	defer resp.Body.Close()
+
+	frames := 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), "data: ") {
+			frames++
+		}
+	}
+	if frames != 3 {
+		t.Errorf("received %d frames, want 3 (err: %v)", frames, scanner.Err())
+	}
+}
+
+var uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestSSESessionIDs(t *testing.T) {
+	s := newTestServer()
+	ts := httptest.NewServer(http.HandlerFunc(s.handleSSE))
+	defer ts.Close()
+
+	const sessions = 100
+	ids := make(chan string, sessions)
+	var wg sync.WaitGroup
+	for range sessions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := http.Get(ts.URL)
+			if err != nil {
+				t.Errorf("GET: %v", err)
+				return
+			}
+			defer resp.Body.Close()
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				if id, ok := strings.CutPrefix(scanner.Text(), "data: /message?session_id="); ok {
+					ids <- id
+					return
+				}
+			}
+			t.Errorf("no endpoint event (err: %v)", scanner.Err())
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[string]bool)
+	for id := range ids {
+		if !uuidV4.MatchString(id) {
+			t.Errorf("session ID %q is not a UUID v4", id)
+		}
+		if seen[id] {
+			t.Errorf("duplicate session ID %q", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != sessions {
+		t.Errorf("got %d session IDs, want %d", len(seen), sessions)
+	}
+}
@@ -0,0 +1,1966 @@
+// Package server implements the MCP tool handlers.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/logging"
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// toolHandler runs a single MCP tool call with already-resolved params.
+type toolHandler func(ctx context.Context, params json.RawMessage) (any, error)
+
+// registerTools builds the tool name to handler table used by callTool.
+// A tool is only advertised when it has both a definition and a handler.
+func (s *Server) registerTools() {
+	s.tools = map[string]toolHandler{
+		"browser_tabs_list":                    s.toolTabsList,
+		"browser_tab_activate":                 s.toolTabActivate,
+		"browser_tab_navigate":                 s.toolTabNavigate,
+		"browser_tab_close":                    s.toolTabClose,
+		"browser_tab_screenshot":               s.toolTabScreenshot,
+		"browser_page_content":                 s.toolPageContent,
+		"browser_page_click":                   s.toolPageClick,
+		"browser_page_fill":                    s.toolPageFill,
+		"browser_page_scroll":                  s.toolPageScroll,
+		"browser_page_execute":                 s.toolPageExecute,
+		"browser_page_find":                    s.toolPageFind,
+		"browser_page_accessibility_tree":      s.toolPageAccessibilityTree,
+		"browser_page_evaluate_xpath":          s.toolPageEvaluateXpath,
+		"browser_page_observe_mutations":       s.toolPageObserveMutations,
+		"browser_downloads_list":               s.toolDownloadsList,
+		"browser_downloads_open":               s.toolDownloadsOpen,
+		"browser_downloads_cancel":             s.toolDownloadsCancel,
+		"browser_downloads_erase":              s.toolDownloadsErase,
+		"browser_page_get_bounding_rect":       s.toolPageGetBoundingRect,
+		"browser_tabs_batch":                   s.toolTabsBatch,
+		"browser_page_scroll_to_element":       s.toolPageScrollToElement,
+		"browser_page_check_checkbox":          s.toolPageCheckCheckbox,
+		"browser_page_uncheck_checkbox":        s.toolPageUncheckCheckbox,
+		"browser_page_focus":                   s.toolPageFocus,
+		"browser_page_blur":                    s.toolPageBlur,
+		"browser_page_alert_handle":            s.toolPageAlertHandle,
+		"browser_page_get_pending_dialog":      s.toolPageGetPendingDialog,
+		"browser_bookmarks_list":               s.toolBookmarksList,
+		"browser_bookmarks_create":             s.toolBookmarksCreate,
+		"browser_bookmarks_delete":             s.toolBookmarksDelete,
+		"browser_page_iframe_list":             s.toolPageIframeList,
+		"browser_page_switch_to_iframe":        s.toolPageSwitchToIframe,
+		"browser_page_get_console_logs":        s.toolPageGetConsoleLogs,
+		"browser_page_wait_for_navigation":     s.toolPageWaitForNavigation,
+		"browser_page_get_meta_tags":           s.toolPageGetMetaTags,
+		"browser_page_get_og_tags":             s.toolPageGetOgTags,
+		"browser_page_get_network_requests":    s.toolPageGetNetworkRequests,
+		"browser_tab_mute":                     s.toolTabMute,
+		"browser_tab_unmute":                   s.toolTabUnmute,
+		"browser_page_pdf":                     s.toolPagePdf,
+		"browser_page_iframe_execute_script":   s.toolPageIframeExecuteScript,
+		"browser_page_highlight_elements":      s.toolPageHighlightElements,
+		"browser_page_get_element_by_label":    s.toolPageGetElementByLabel,
+		"browser_page_fill_by_label":           s.toolPageFillByLabel,
+		"browser_page_emulate_geolocation":     s.toolPageEmulateGeolocation,
+		"browser_page_clear_geolocation":       s.toolPageClearGeolocation,
+		"browser_page_emulate_device":          s.toolPageEmulateDevice,
+		"browser_page_emulate_device_preset":   s.toolPageEmulateDevicePreset,
+		"browser_page_get_page_errors":         s.toolPageGetPageErrors,
+		"browser_page_intercept_response":      s.toolPageInterceptResponse,
+		"browser_page_get_title":               s.toolPageGetTitle,
+		"browser_page_get_url":                 s.toolPageGetUrl,
+		"browser_page_content_markdown":        s.toolPageContentMarkdown,
+		"browser_extension_info":               s.toolExtensionInfo,
+		"browser_page_run_snippet":             s.toolPageRunSnippet,
+		"browser_tabs_filter":                  s.toolTabsFilter,
+		"browser_page_get_structured_data":     s.toolPageGetStructuredData,
+		"browser_page_screenshot_element":      s.toolPageScreenshotElement,
+		"browser_page_emulate_timezone":        s.toolPageEmulateTimezone,
+		"browser_page_clear_input":             s.toolPageClearInput,
+		"browser_page_get_page_source":         s.toolPageGetPageSource,
+		"browser_page_wait_for_url":            s.toolPageWaitForUrl,
+		"browser_page_list_storage_keys":       s.toolPageListStorageKeys,
+		"browser_page_clear_storage":           s.toolPageClearStorage,
+		"browser_tab_reload":                   s.toolTabReload,
+		"browser_page_get_selection":           s.toolPageGetSelection,
+		"browser_page_set_selection":           s.toolPageSetSelection,
+		"browser_page_click_at_coordinates":    s.toolPageClickAtCoordinates,
+		"browser_page_get_element_text":        s.toolPageGetElementText,
+		"browser_page_get_element_html":        s.toolPageGetElementHtml,
+		"browser_page_wait_for_text":           s.toolPageWaitForText,
+		"browser_page_get_scroll_position":     s.toolPageGetScrollPosition,
+		"browser_page_get_scroll_size":         s.toolPageGetScrollSize,
+		"browser_page_emulate_media":           s.toolPageEmulateMedia,
+		"browser_page_count_elements":          s.toolPageCountElements,
+		"browser_page_element_exists":          s.toolPageElementExists,
+		"browser_page_get_form_fields":         s.toolPageGetFormFields,
+		"browser_page_scroll_by":               s.toolPageScrollBy,
+		"browser_page_scroll_element_by":       s.toolPageScrollElementBy,
+		"browser_page_get_links":               s.toolPageGetLinks,
+		"browser_page_get_images":              s.toolPageGetImages,
+		"browser_page_trigger_event":           s.toolPageTriggerEvent,
+		"browser_page_get_table_data":          s.toolPageGetTableData,
+		"browser_page_get_dropdown_options":    s.toolPageGetDropdownOptions,
+		"browser_page_get_selected_option":     s.toolPageGetSelectedOption,
+		"browser_tab_move":                     s.toolTabMove,
+		"browser_tab_move_to_new_window":       s.toolTabMoveToNewWindow,
+		"browser_tab_focus_and_navigate":       s.toolTabFocusAndNavigate,
+		"browser_page_get_video_info":          s.toolPageGetVideoInfo,
+		"browser_page_control_video":           s.toolPageControlVideo,
+		"browser_page_execute_async":           s.toolPageExecuteAsync,
+		"browser_page_get_performance_metrics": s.toolPageGetPerformanceMetrics,
+		"browser_page_right_click":             s.toolPageRightClick,
+		"browser_page_double_click":            s.toolPageDoubleClick,
+		"browser_page_get_canvas_data":         s.toolPageGetCanvasData,
+		"browser_page_emulate_dark_mode":       s.toolPageEmulateDarkMode,
+		"browser_tabs_close_multiple":          s.toolTabsCloseMultiple,
+		"browser_tabs_close_others":            s.toolTabsCloseOthers,
+		"browser_page_get_anchor_by_text":      s.toolPageGetAnchorByText,
+		"browser_page_click_anchor_by_text":    s.toolPageClickAnchorByText,
+		"browser_page_set_attribute":           s.toolPageSetAttribute,
+		"browser_page_remove_attribute":        s.toolPageRemoveAttribute,
+		"browser_page_get_all_text_nodes":      s.toolPageGetAllTextNodes,
+		"browser_page_get_visible_text":        s.toolPageGetVisibleText,
+		"browser_page_run_lighthouse":          s.toolPageRunLighthouse,
+		"browser_page_get_color_at":            s.toolPageGetColorAt,
+		"browser_page_get_shadow_dom":          s.toolPageGetShadowDom,
+		"browser_page_query_shadow_dom":        s.toolPageQueryShadowDom,
+		"browser_page_mock_geolocation_track":  s.toolPageMockGeolocationTrack,
+		"browser_page_execute_in_worker":       s.toolPageExecuteInWorker,
+		"browser_page_get_robots_txt":          s.toolPageGetRobotsTxt,
+		"browser_page_check_robots_allowed":    s.toolPageCheckRobotsAllowed,
+		"browser_page_get_sitemap":             s.toolPageGetSitemap,
+		"browser_page_diff_screenshots":        s.toolPageDiffScreenshots,
+		"browser_page_get_web_workers":         s.toolPageGetWebWorkers,
+		"browser_page_get_response_headers":    s.toolPageGetResponseHeaders,
+		"browser_page_wait_for_network_idle":   s.toolPageWaitForNetworkIdle,
+		"browser_page_get_storage_quota":       s.toolPageGetStorageQuota,
+	}
+	for _, t := range s.handler.GetTools() {
+		if _, ok := s.tools[t.Name]; !ok {
+			s.logger.Warn("tool has no handler and will not be advertised", "tool", t.Name)
+		}
+	}
+}
+
+// toolMethods maps tools that need an extension method not every extension
+// implements to that method.
+var toolMethods = map[string]string{
+	"browser_page_run_lighthouse": "browser.lighthouse.run",
+}
+
+// toolList returns the definitions of all registered tools, leaving out
+// those whose method in toolMethods the extension has not announced.
+func (s *Server) toolList() []mcp.Tool {
+	defs := s.handler.GetTools()
+	tools := make([]mcp.Tool, 0, len(defs))
+	for _, t := range defs {
+		if _, ok := s.tools[t.Name]; !ok {
+			continue
+		}
+		if method, ok := toolMethods[t.Name]; ok && !s.extensionSupports(method) {
+			continue
+		}
+		tools = append(tools, t)
+	}
+	return tools
+}
+
+func (s *Server) toolTabsList(ctx context.Context, params json.RawMessage) (any, error) {
+	tabs, err := s.handler.ListTabs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(tabs)
+}
+
+func (s *Server) toolTabActivate(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ActivateTab(ctx, p.TabID); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d activated", p.TabID)), nil
+}
+
+func (s *Server) toolTabNavigate(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int    `json:"tabId"`
+		URL   string `json:"url"`
+	}
+	logger := logging.LoggerFromContext(ctx)
+	logger.Debug("browser_tab_navigate called", "params", string(params))
+	if err := json.Unmarshal(params, &p); err != nil {
+		logger.Error("failed to unmarshal navigate params", "error", err, "params", string(params))
+		return nil, err
+	}
+	logger.Debug("parsed navigate params", "tabId", p.TabID, "url", p.URL)
+	if err := s.handler.NavigateTab(ctx, p.TabID, p.URL); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Navigated tab %d to %s", p.TabID, p.URL)), nil
+}
+
+func (s *Server) toolTabClose(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.CloseTab(ctx, p.TabID); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d closed", p.TabID)), nil
+}
+
+func (s *Server) toolTabScreenshot(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	dataUrl, err := s.handler.ScreenshotTab(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(dataUrl), nil
+}
+
+func (s *Server) toolPageContent(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	content, err := s.handler.GetPageContent(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(content)
+}
+
+func (s *Server) toolPageClick(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ClickElement(ctx, p.TabID, p.Selector); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Clicked element: %s", p.Selector)), nil
+}
+
+func (s *Server) toolPageFill(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+		Value    string `json:"value"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.FillInput(ctx, p.TabID, p.Selector, p.Value); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Filled %s with: %s", p.Selector, p.Value)), nil
+}
+
+func (s *Server) toolPageScroll(ctx context.Context, params json.RawMessage) (any, error) {
+	var p mcp.ScrollPageParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Selector != "" {
+		if err := s.handler.ScrollToElement(ctx, p.TabID, p.Selector, p.Behavior); err != nil {
+			return nil, err
+		}
+		return makeTextResult(fmt.Sprintf("Scrolled to element: %s", p.Selector)), nil
+	}
+	if err := s.handler.ScrollPage(ctx, p.TabID, p.X, p.Y); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Scrolled to %d, %d", p.X, p.Y)), nil
+}
+
+func (s *Server) toolPageExecute(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID  int    `json:"tabId"`
+		Script string `json:"script"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	result, err := s.handler.ExecuteScript(ctx, p.TabID, p.Script)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(result)
+}
+
+func (s *Server) toolPageFind(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	result, err := s.handler.FindElements(ctx, p.TabID, p.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(result)
+}
+
+func (s *Server) toolPageAccessibilityTree(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	tree, err := s.handler.GetAccessibilityTree(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(tree)
+}
+
+func (s *Server) toolPageEvaluateXpath(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID           int    `json:"tabId"`
+		Expression      string `json:"expression"`
+		ContextSelector string `json:"contextSelector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	result, err := s.handler.EvaluateXPath(ctx, p.TabID, p.Expression, p.ContextSelector)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(result)
+}
+
+func (s *Server) toolPageObserveMutations(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int    `json:"tabId"`
+		Selector  string `json:"selector"`
+		TimeoutMs int    `json:"timeoutMs"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.TimeoutMs <= 0 {
+		p.TimeoutMs = 5000
+	}
+	mutations, err := s.handler.ObserveMutations(ctx, p.TabID, p.Selector, time.Duration(p.TimeoutMs)*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(mutations)
+}
+
+func (s *Server) toolDownloadsList(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		Query string `json:"query"`
+		Limit int    `json:"limit"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	downloads, err := s.handler.ListDownloads(ctx, p.Query, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(downloads)
+}
+
+func (s *Server) toolDownloadsOpen(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		DownloadID int `json:"downloadId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.OpenDownload(ctx, p.DownloadID); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Download %d opened", p.DownloadID)), nil
+}
+
+func (s *Server) toolDownloadsCancel(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		DownloadID int `json:"downloadId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.CancelDownload(ctx, p.DownloadID); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Download %d cancelled", p.DownloadID)), nil
+}
+
+func (s *Server) toolDownloadsErase(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		DownloadID int `json:"downloadId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.EraseDownload(ctx, p.DownloadID); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Download %d erased", p.DownloadID)), nil
+}
+
+func (s *Server) toolPageGetBoundingRect(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID               int    `json:"tabId"`
+		Selector            string `json:"selector"`
+		IncludeScrollOffset bool   `json:"includeScrollOffset"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	rect, err := s.handler.GetBoundingRect(ctx, p.TabID, p.Selector, p.IncludeScrollOffset)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(rect)
+}
+
+func (s *Server) toolTabsBatch(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		Ops []mcp.TabOp `json:"ops"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	results, err := s.handler.BatchTabOp(ctx, p.Ops)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(results)
+}
+
+func (s *Server) toolPageScrollToElement(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+		Behavior string `json:"behavior"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ScrollToElement(ctx, p.TabID, p.Selector, p.Behavior); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Scrolled to element: %s", p.Selector)), nil
+}
+
+func (s *Server) toolPageCheckCheckbox(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.SetCheckbox(ctx, p.TabID, p.Selector, true); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Checked checkbox: %s", p.Selector)), nil
+}
+
+func (s *Server) toolPageUncheckCheckbox(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.SetCheckbox(ctx, p.TabID, p.Selector, false); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Unchecked checkbox: %s", p.Selector)), nil
+}
+
+func (s *Server) toolPageFocus(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.FocusElement(ctx, p.TabID, p.Selector); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Focused element: %s", p.Selector)), nil
+}
+
+func (s *Server) toolPageBlur(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.BlurElement(ctx, p.TabID, p.Selector); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Blurred element: %s", p.Selector)), nil
+}
+
+func (s *Server) toolPageAlertHandle(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID      int    `json:"tabId"`
+		Action     string `json:"action"`
+		PromptText string `json:"promptText"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.HandleDialog(ctx, p.TabID, p.Action, p.PromptText); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Dialog %sed in tab %d", p.Action, p.TabID)), nil
+}
+
+func (s *Server) toolPageGetPendingDialog(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	dialog, err := s.handler.GetPendingDialog(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	if dialog == nil {
+		return makeTextResult("No dialog open"), nil
+	}
+	return makeJSONResult(dialog)
+}
+
+func (s *Server) toolBookmarksList(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		Query string `json:"query"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	bookmarks, err := s.handler.ListBookmarks(ctx, p.Query)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(bookmarks)
+}
+
+func (s *Server) toolBookmarksCreate(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		ParentID string `json:"parentId"`
+		Title    string `json:"title"`
+		URL      string `json:"url"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	bookmark, err := s.handler.CreateBookmark(ctx, p.ParentID, p.Title, p.URL)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(bookmark)
+}
+
+func (s *Server) toolBookmarksDelete(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.DeleteBookmark(ctx, p.ID); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Bookmark %s deleted", p.ID)), nil
+}
+
+func (s *Server) toolPageIframeList(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	frames, err := s.handler.ListIframes(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(frames)
+}
+
+func (s *Server) toolPageSwitchToIframe(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID          int    `json:"tabId"`
+		IframeSelector string `json:"iframeSelector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	content, err := s.handler.GetIframeContent(ctx, p.TabID, p.IframeSelector)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(content)
+}
+
+func (s *Server) toolPageGetConsoleLogs(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int    `json:"tabId"`
+		Level string `json:"level"`
+		Limit int    `json:"limit"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	logs, err := s.handler.GetConsoleLogs(ctx, p.TabID, p.Level, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(logs)
+}
+
+func (s *Server) toolPageWaitForNavigation(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int    `json:"tabId"`
+		WaitUntil string `json:"waitUntil"`
+		TimeoutMs int    `json:"timeoutMs"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.TimeoutMs <= 0 {
+		p.TimeoutMs = 25000
+	}
+	if err := s.handler.WaitForNavigation(ctx, p.TabID, time.Duration(p.TimeoutMs)*time.Millisecond, p.WaitUntil); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d loaded", p.TabID)), nil
+}
+
+func (s *Server) toolPageGetMetaTags(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	tags, err := s.handler.GetMetaTags(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(tags)
+}
+
+func (s *Server) toolPageGetOgTags(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	tags, err := s.handler.GetOpenGraphTags(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(tags)
+}
+
+func (s *Server) toolPageGetNetworkRequests(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID      int    `json:"tabId"`
+		DurationMs int    `json:"durationMs"`
+		URLPattern string `json:"urlPattern"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.DurationMs <= 0 {
+		p.DurationMs = 5000
+	}
+	requests, err := s.handler.MonitorNetworkRequests(ctx, p.TabID, p.DurationMs, p.URLPattern)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(requests)
+}
+
+func (s *Server) toolTabMute(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.MuteTab(ctx, p.TabID, true); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d muted", p.TabID)), nil
+}
+
+func (s *Server) toolTabUnmute(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.MuteTab(ctx, p.TabID, false); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d unmuted", p.TabID)), nil
+}
+
+func (s *Server) toolPagePdf(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+		mcp.PDFOptions
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	pdf, err := s.handler.PrintToPDF(ctx, p.TabID, p.PDFOptions)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(pdf), nil
+}
+
+func (s *Server) toolPageIframeExecuteScript(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID         int    `json:"tabId"`
+		FrameSelector string `json:"frameSelector"`
+		Script        string `json:"script"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	result, err := s.handler.ExecuteScriptInFrame(ctx, p.TabID, p.FrameSelector, p.Script)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(result)
+}
+
+func (s *Server) toolPageHighlightElements(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID      int    `json:"tabId"`
+		Selector   string `json:"selector"`
+		Color      string `json:"color"`
+		DurationMs int    `json:"durationMs"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Color == "" {
+		p.Color = "red"
+	}
+	if p.DurationMs <= 0 {
+		p.DurationMs = 2000
+	}
+	count, err := s.handler.HighlightElements(ctx, p.TabID, p.Selector, p.Color, p.DurationMs)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Highlighted %d elements", count)), nil
+}
+
+func (s *Server) toolPageGetElementByLabel(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int    `json:"tabId"`
+		LabelText string `json:"labelText"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	info, err := s.handler.GetElementByLabel(ctx, p.TabID, p.LabelText)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(info)
+}
+
+func (s *Server) toolPageFillByLabel(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int    `json:"tabId"`
+		LabelText string `json:"labelText"`
+		Value     string `json:"value"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.FillByLabel(ctx, p.TabID, p.LabelText, p.Value); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Filled %q with: %s", p.LabelText, p.Value)), nil
+}
+
+func (s *Server) toolPageEmulateGeolocation(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int     `json:"tabId"`
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Accuracy  float64 `json:"accuracy"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Accuracy <= 0 {
+		p.Accuracy = 10
+	}
+	if err := s.handler.EmulateGeolocation(ctx, p.TabID, p.Latitude, p.Longitude, p.Accuracy); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d geolocation set to %g, %g", p.TabID, p.Latitude, p.Longitude)), nil
+}
+
+func (s *Server) toolPageClearGeolocation(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ClearGeolocation(ctx, p.TabID); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d geolocation override cleared", p.TabID)), nil
+}
+
+func (s *Server) toolPageEmulateDevice(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+		mcp.DeviceProfile
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.EmulateDevice(ctx, p.TabID, p.DeviceProfile); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d emulating %dx%d device", p.TabID, p.Width, p.Height)), nil
+}
+
+func (s *Server) toolPageEmulateDevicePreset(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID  int    `json:"tabId"`
+		Device string `json:"device"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	profile, ok := mcp.DeviceProfiles[p.Device]
+	if !ok {
+		return nil, fmt.Errorf("unknown device: %s", p.Device)
+	}
+	if err := s.handler.EmulateDevice(ctx, p.TabID, profile); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d emulating %s", p.TabID, p.Device)), nil
+}
+
+func (s *Server) toolPageGetPageErrors(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID          int  `json:"tabId"`
+		ClearAfterRead bool `json:"clearAfterRead"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	errs, err := s.handler.GetPageErrors(ctx, p.TabID, p.ClearAfterRead)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(errs)
+}
+
+func (s *Server) toolPageInterceptResponse(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID      int    `json:"tabId"`
+		URLPattern string `json:"urlPattern"`
+		TimeoutMs  int    `json:"timeoutMs"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.TimeoutMs <= 0 {
+		p.TimeoutMs = 10000
+	}
+	response, err := s.handler.InterceptResponse(ctx, p.TabID, p.URLPattern, p.TimeoutMs)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(response)
+}
+
+func (s *Server) toolPageGetTitle(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	title, err := s.handler.GetTitle(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(title), nil
+}
+
+func (s *Server) toolPageGetUrl(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	url, err := s.handler.GetCurrentURL(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(url), nil
+}
+
+func (s *Server) toolPageContentMarkdown(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	markdown, err := s.handler.GetPageContentMarkdown(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(markdown), nil
+}
+
+func (s *Server) toolExtensionInfo(ctx context.Context, params json.RawMessage) (any, error) {
+	info := s.ExtensionInfo()
+	if info == nil {
+		return nil, fmt.Errorf("extension has not announced itself")
+	}
+	return makeJSONResult(info)
+}
+
+func (s *Server) toolPageRunSnippet(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int            `json:"tabId"`
+		Name  string         `json:"name"`
+		Args  map[string]any `json:"args"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	result, err := s.handler.RunSnippet(ctx, p.TabID, p.Name, p.Args)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(result)
+}
+
+func (s *Server) toolTabsFilter(ctx context.Context, params json.RawMessage) (any, error) {
+	var filter mcp.TabFilter
+	if err := json.Unmarshal(params, &filter); err != nil {
+		return nil, err
+	}
+	tabs, err := s.handler.FilterTabs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(tabs)
+}
+
+func (s *Server) toolPageGetStructuredData(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	data, err := s.handler.GetStructuredData(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(data)
+}
+
+func (s *Server) toolPageScreenshotElement(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+		mcp.ScreenshotOptions
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	dataURL, err := s.handler.ScreenshotElement(ctx, p.TabID, p.Selector, p.ScreenshotOptions)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(dataURL), nil
+}
+
+func (s *Server) toolPageEmulateTimezone(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Timezone string `json:"timezone"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.EmulateTimezone(ctx, p.TabID, p.Timezone); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d emulating timezone %s", p.TabID, p.Timezone)), nil
+}
+
+func (s *Server) toolPageClearInput(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ClearInput(ctx, p.TabID, p.Selector); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Cleared element: %s", p.Selector)), nil
+}
+
+func (s *Server) toolPageGetPageSource(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	source, err := s.handler.GetPageSource(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(source), nil
+}
+
+func (s *Server) toolPageWaitForUrl(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int    `json:"tabId"`
+		Pattern   string `json:"pattern"`
+		TimeoutMs int    `json:"timeoutMs"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.TimeoutMs <= 0 {
+		p.TimeoutMs = 25000
+	}
+	url, err := s.handler.WaitForURL(ctx, p.TabID, p.Pattern, time.Duration(p.TimeoutMs)*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(url), nil
+}
+
+func (s *Server) toolPageListStorageKeys(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID       int    `json:"tabId"`
+		StorageType string `json:"storageType"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	keys, err := s.handler.ListStorageKeys(ctx, p.TabID, p.StorageType)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(keys)
+}
+
+func (s *Server) toolPageClearStorage(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID       int    `json:"tabId"`
+		StorageType string `json:"storageType"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ClearStorage(ctx, p.TabID, p.StorageType); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d storage cleared", p.TabID)), nil
+}
+
+func (s *Server) toolTabReload(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID       int  `json:"tabId"`
+		BypassCache bool `json:"bypassCache"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ReloadTab(ctx, p.TabID, p.BypassCache); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d reloading", p.TabID)), nil
+}
+
+func (s *Server) toolPageGetSelection(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	text, err := s.handler.GetSelection(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(text), nil
+}
+
+func (s *Server) toolPageSetSelection(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+		Start    int    `json:"start"`
+		End      int    `json:"end"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.SetSelection(ctx, p.TabID, p.Selector, p.Start, p.End); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Selected characters %d-%d of %s", p.Start, p.End, p.Selector)), nil
+}
+
+func (s *Server) toolPageClickAtCoordinates(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID  int    `json:"tabId"`
+		X      int    `json:"x"`
+		Y      int    `json:"y"`
+		Button string `json:"button"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ClickAt(ctx, p.TabID, p.X, p.Y, p.Button); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Clicked at (%d, %d)", p.X, p.Y)), nil
+}
+
+func (s *Server) toolPageGetElementText(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	text, err := s.handler.GetElementText(ctx, p.TabID, p.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(text), nil
+}
+
+func (s *Server) toolPageGetElementHtml(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	html, err := s.handler.GetElementHTML(ctx, p.TabID, p.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(html), nil
+}
+
+func (s *Server) toolPageWaitForText(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int    `json:"tabId"`
+		Text      string `json:"text"`
+		Present   *bool  `json:"present"`
+		TimeoutMs int    `json:"timeoutMs"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.TimeoutMs <= 0 {
+		p.TimeoutMs = 25000
+	}
+	present := p.Present == nil || *p.Present
+	if err := s.handler.WaitForText(ctx, p.TabID, p.Text, present, time.Duration(p.TimeoutMs)*time.Millisecond); err != nil {
+		return nil, err
+	}
+	if !present {
+		return makeTextResult(fmt.Sprintf("Text %q disappeared", p.Text)), nil
+	}
+	return makeTextResult(fmt.Sprintf("Text %q appeared", p.Text)), nil
+}
+
+func (s *Server) toolPageGetScrollPosition(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	x, y, err := s.handler.GetScrollPosition(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(map[string]int{"x": x, "y": y})
+}
+
+func (s *Server) toolPageGetScrollSize(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	width, height, err := s.handler.GetScrollSize(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(map[string]int{"width": width, "height": height})
+}
+
+func (s *Server) toolPageEmulateMedia(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int    `json:"tabId"`
+		Media string `json:"media"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.EmulateMedia(ctx, p.TabID, p.Media); err != nil {
+		return nil, err
+	}
+	if p.Media == "" {
+		return makeTextResult(fmt.Sprintf("Tab %d media emulation reset", p.TabID)), nil
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d emulating %s media", p.TabID, p.Media)), nil
+}
+
+func (s *Server) toolPageCountElements(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	count, err := s.handler.CountElements(ctx, p.TabID, p.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(strconv.Itoa(count)), nil
+}
+
+func (s *Server) toolPageElementExists(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	exists, err := s.handler.ElementExists(ctx, p.TabID, p.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(strconv.FormatBool(exists)), nil
+}
+
+func (s *Server) toolPageGetFormFields(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID        int    `json:"tabId"`
+		FormSelector string `json:"formSelector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	fields, err := s.handler.GetFormFields(ctx, p.TabID, p.FormSelector)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(fields)
+}
+
+func (s *Server) toolPageScrollBy(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		DeltaX   int    `json:"deltaX"`
+		DeltaY   int    `json:"deltaY"`
+		Behavior string `json:"behavior"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ScrollBy(ctx, p.TabID, p.DeltaX, p.DeltaY, p.Behavior); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Scrolled by (%d, %d)", p.DeltaX, p.DeltaY)), nil
+}
+
+func (s *Server) toolPageScrollElementBy(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+		DeltaX   int    `json:"deltaX"`
+		DeltaY   int    `json:"deltaY"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ScrollElementBy(ctx, p.TabID, p.Selector, p.DeltaX, p.DeltaY); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Scrolled %s by (%d, %d)", p.Selector, p.DeltaX, p.DeltaY)), nil
+}
+
+func (s *Server) toolPageGetLinks(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+		mcp.LinkFilter
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	links, err := s.handler.GetLinks(ctx, p.TabID, p.LinkFilter)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(links)
+}
+
+func (s *Server) toolPageGetImages(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID         int  `json:"tabId"`
+		LoadedOnly    bool `json:"loadedOnly"`
+		IncludeBase64 bool `json:"includeBase64"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	images, err := s.handler.GetImages(ctx, p.TabID, p.LoadedOnly, p.IncludeBase64)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(images)
+}
+
+func (s *Server) toolPageTriggerEvent(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int            `json:"tabId"`
+		Selector  string         `json:"selector"`
+		EventType string         `json:"eventType"`
+		EventInit map[string]any `json:"eventInit"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.TriggerEvent(ctx, p.TabID, p.Selector, p.EventType, p.EventInit); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Dispatched %s on %s", p.EventType, p.Selector)), nil
+}
+
+func (s *Server) toolPageGetTableData(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	table, err := s.handler.GetTableData(ctx, p.TabID, p.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(table)
+}
+
+func (s *Server) toolPageGetDropdownOptions(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	options, err := s.handler.GetDropdownOptions(ctx, p.TabID, p.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(options)
+}
+
+func (s *Server) toolPageGetSelectedOption(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	option, err := s.handler.GetSelectedOption(ctx, p.TabID, p.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(option)
+}
+
+func (s *Server) toolTabMove(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int `json:"tabId"`
+		WindowID int `json:"windowId"`
+		Index    int `json:"index"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.MoveTab(ctx, p.TabID, p.WindowID, p.Index); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d moved to index %d", p.TabID, p.Index)), nil
+}
+
+func (s *Server) toolTabMoveToNewWindow(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	window, err := s.handler.MoveTabToNewWindow(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(window)
+}
+
+func (s *Server) toolTabFocusAndNavigate(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID       int    `json:"tabId"`
+		URL         string `json:"url"`
+		WaitForLoad bool   `json:"waitForLoad"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.FocusAndNavigate(ctx, p.TabID, p.URL, p.WaitForLoad); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Navigated tab %d to %s", p.TabID, p.URL)), nil
+}
+
+func (s *Server) toolPageGetVideoInfo(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	info, err := s.handler.GetVideoInfo(ctx, p.TabID, p.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(info)
+}
+
+func (s *Server) toolPageControlVideo(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+		Action   string `json:"action"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ControlVideo(ctx, p.TabID, p.Selector, p.Action); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Video %s: %s", p.Selector, p.Action)), nil
+}
+
+func (s *Server) toolPageExecuteAsync(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int    `json:"tabId"`
+		Script    string `json:"script"`
+		TimeoutMs int    `json:"timeoutMs"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	result, err := s.handler.ExecuteAsyncScript(ctx, p.TabID, p.Script, p.TimeoutMs)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(result)
+}
+
+func (s *Server) toolPageGetPerformanceMetrics(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	metrics, err := s.handler.GetPerformanceMetrics(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(metrics)
+}
+
+func (s *Server) toolPageRightClick(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.RightClick(ctx, p.TabID, p.Selector); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Right-clicked element: %s", p.Selector)), nil
+}
+
+func (s *Server) toolPageDoubleClick(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.DoubleClick(ctx, p.TabID, p.Selector); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Double-clicked element: %s", p.Selector)), nil
+}
+
+func (s *Server) toolPageGetCanvasData(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID    int    `json:"tabId"`
+		Selector string `json:"selector"`
+		Format   string `json:"format"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	dataURL, err := s.handler.GetCanvasData(ctx, p.TabID, p.Selector, p.Format)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(dataURL), nil
+}
+
+func (s *Server) toolPageEmulateDarkMode(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID  int    `json:"tabId"`
+		Scheme string `json:"scheme"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.EmulateColorScheme(ctx, p.TabID, p.Scheme); err != nil {
+		return nil, err
+	}
+	if p.Scheme == "" {
+		return makeTextResult(fmt.Sprintf("Tab %d color scheme emulation reset", p.TabID)), nil
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d emulating %s color scheme", p.TabID, p.Scheme)), nil
+}
+
+func (s *Server) toolTabsCloseMultiple(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabIDs []int `json:"tabIds"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.CloseMultipleTabs(ctx, p.TabIDs); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Closed %d tabs", len(p.TabIDs))), nil
+}
+
+func (s *Server) toolTabsCloseOthers(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	closed, err := s.handler.CloseOtherTabs(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Closed %d tabs, kept tab %d", closed, p.TabID)), nil
+}
+
+func (s *Server) toolPageGetAnchorByText(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int    `json:"tabId"`
+		Text  string `json:"text"`
+		Exact bool   `json:"exact"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	link, err := s.handler.GetAnchorByText(ctx, p.TabID, p.Text, p.Exact)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(link)
+}
+
+func (s *Server) toolPageClickAnchorByText(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int    `json:"tabId"`
+		Text  string `json:"text"`
+		Exact bool   `json:"exact"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.ClickAnchorByText(ctx, p.TabID, p.Text, p.Exact); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Clicked link %q", p.Text)), nil
+}
+
+func (s *Server) toolPageSetAttribute(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int    `json:"tabId"`
+		Selector  string `json:"selector"`
+		Attribute string `json:"attribute"`
+		Value     string `json:"value"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.SetAttribute(ctx, p.TabID, p.Selector, p.Attribute, p.Value); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Set %s on %s", p.Attribute, p.Selector)), nil
+}
+
+func (s *Server) toolPageRemoveAttribute(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int    `json:"tabId"`
+		Selector  string `json:"selector"`
+		Attribute string `json:"attribute"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if err := s.handler.RemoveAttribute(ctx, p.TabID, p.Selector, p.Attribute); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Removed %s from %s", p.Attribute, p.Selector)), nil
+}
+
+func (s *Server) toolPageGetAllTextNodes(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int `json:"tabId"`
+		MinLength int `json:"minLength"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.MinLength <= 0 {
+		p.MinLength = 1
+	}
+	texts, err := s.handler.GetTextNodes(ctx, p.TabID, p.MinLength)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(texts)
+}
+
+func (s *Server) toolPageGetVisibleText(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	text, err := s.handler.GetVisibleText(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(text), nil
+}
+
+func (s *Server) toolPageRunLighthouse(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID      int      `json:"tabId"`
+		Categories []string `json:"categories"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	result, err := s.handler.RunLighthouse(ctx, p.TabID, p.Categories)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(result)
+}
+
+func (s *Server) toolPageGetColorAt(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+		X     int `json:"x"`
+		Y     int `json:"y"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	color, err := s.handler.GetColorAt(ctx, p.TabID, p.X, p.Y)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(color)
+}
+
+func (s *Server) toolPageGetShadowDom(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID        int    `json:"tabId"`
+		HostSelector string `json:"hostSelector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	content, err := s.handler.GetShadowRoot(ctx, p.TabID, p.HostSelector)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(content)
+}
+
+func (s *Server) toolPageQueryShadowDom(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID         int    `json:"tabId"`
+		HostSelector  string `json:"hostSelector"`
+		InnerSelector string `json:"innerSelector"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	elements, err := s.handler.QueryShadowRoot(ctx, p.TabID, p.HostSelector, p.InnerSelector)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(elements)
+}
+
+func (s *Server) toolPageMockGeolocationTrack(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID      int               `json:"tabId"`
+		Positions  []mcp.GeoPosition `json:"positions"`
+		IntervalMs int               `json:"intervalMs"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.IntervalMs <= 0 {
+		p.IntervalMs = 1000
+	}
+	for i := range p.Positions {
+		if p.Positions[i].Accuracy <= 0 {
+			p.Positions[i].Accuracy = 10
+		}
+	}
+	if err := s.handler.MockGeolocationTrack(ctx, p.TabID, p.Positions, p.IntervalMs); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d geolocation playing %d positions every %dms", p.TabID, len(p.Positions), p.IntervalMs)), nil
+}
+
+func (s *Server) toolPageExecuteInWorker(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int    `json:"tabId"`
+		Script    string `json:"script"`
+		TimeoutMs int    `json:"timeoutMs"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	result, err := s.handler.ExecuteInWorker(ctx, p.TabID, p.Script, p.TimeoutMs)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(result)
+}
+
+func (s *Server) toolPageGetRobotsTxt(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	robots, err := s.handler.GetRobotsTxt(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(robots), nil
+}
+
+func (s *Server) toolPageCheckRobotsAllowed(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID     int    `json:"tabId"`
+		Path      string `json:"path"`
+		UserAgent string `json:"userAgent"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.UserAgent == "" {
+		p.UserAgent = "*"
+	}
+	allowed, err := s.handler.CheckRobotsAllowed(ctx, p.TabID, p.Path, p.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+	return makeTextResult(strconv.FormatBool(allowed)), nil
+}
+
+func (s *Server) toolPageGetSitemap(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	entries, err := s.handler.GetSitemap(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(entries)
+}
+
+func (s *Server) toolPageDiffScreenshots(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID          int     `json:"tabId"`
+		BaselineBase64 string  `json:"baselineBase64"`
+		Threshold      float64 `json:"threshold"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	diff, err := s.handler.DiffScreenshots(ctx, p.TabID, p.BaselineBase64, p.Threshold)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(diff)
+}
+
+func (s *Server) toolPageGetWebWorkers(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	workers, err := s.handler.GetWebWorkers(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(workers)
+}
+
+func (s *Server) toolPageGetResponseHeaders(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	headers, err := s.handler.GetResponseHeaders(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(headers)
+}
+
+func (s *Server) toolPageWaitForNetworkIdle(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID      int `json:"tabId"`
+		IdleTimeMs int `json:"idleTimeMs"`
+		TimeoutMs  int `json:"timeoutMs"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	if p.IdleTimeMs <= 0 {
+		p.IdleTimeMs = 500
+	}
+	if p.TimeoutMs <= 0 {
+		p.TimeoutMs = 10000
+	}
+	p.TimeoutMs = min(p.TimeoutMs, 25000)
+	idleTime := time.Duration(p.IdleTimeMs) * time.Millisecond
+	if err := s.handler.WaitForNetworkIdle(ctx, p.TabID, idleTime, time.Duration(p.TimeoutMs)*time.Millisecond); err != nil {
+		return nil, err
+	}
+	return makeTextResult(fmt.Sprintf("Tab %d network idle for %dms", p.TabID, p.IdleTimeMs)), nil
+}
+
+func (s *Server) toolPageGetStorageQuota(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		TabID int `json:"tabId"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	quota, err := s.handler.GetStorageQuota(ctx, p.TabID)
+	if err != nil {
+		return nil, err
+	}
+	return makeJSONResult(quota)
+}
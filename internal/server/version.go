@@ -0,0 +1,141 @@
+// Package server provides MCP protocol and extension version negotiation.
+package server
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+// defaultProtocolVersion is assumed for clients that do not declare a
+// protocol version in their initialize request.
+const defaultProtocolVersion = "2024-11-05"
+
+// defaultProtocolVersions are the MCP protocol versions served by default.
+var defaultProtocolVersions = []string{"2024-11-05", "2025-03-26", "2025-06-18"}
+
+// WithProtocolVersions sets the MCP protocol versions the server accepts.
+func WithProtocolVersions(versions ...string) Option {
+	return func(s *Server) {
+		if len(versions) > 0 {
+			s.protocolVersions = append([]string(nil), versions...)
+		}
+	}
+}
+
+// negotiateProtocolVersion selects the highest supported version that does
+// not exceed the client's requested version. It returns an MCP error with
+// code -32602 listing the supported versions if there is none.
+func (s *Server) negotiateProtocolVersion(requested string) (string, error) {
+	if requested == "" {
+		requested = defaultProtocolVersion
+	}
+
+	supported := s.sortedProtocolVersions()
+	for i := len(supported) - 1; i >= 0; i-- {
+		if compareVersions(supported[i], requested) <= 0 {
+			return supported[i], nil
+		}
+	}
+	return "", &mcp.Error{
+		Code:    -32602,
+		Message: "unsupported protocol version: " + requested,
+		Data:    map[string]any{"supportedVersions": supported},
+	}
+}
+
+// latestProtocolVersion returns the newest supported protocol version.
+func (s *Server) latestProtocolVersion() string {
+	supported := s.sortedProtocolVersions()
+	return supported[len(supported)-1]
+}
+
+// sortedProtocolVersions returns the supported versions, oldest first.
+func (s *Server) sortedProtocolVersions() []string {
+	versions := append([]string(nil), s.protocolVersions...)
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) < 0
+	})
+	return versions
+}
+
+// compareVersions orders version strings by their numeric components, so
+// both date-style ("2024-11-05") and semver-style ("1.2.3") versions sort
+// naturally. It returns -1, 0 or 1.
+func compareVersions(a, b string) int {
+	split := func(v string) []string {
+		return strings.FieldsFunc(v, func(r rune) bool { return r == '-' || r == '.' })
+	}
+	pa, pb := split(a), split(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			y, _ = strconv.Atoi(pb[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// hostVersion is the version of this host. An extension whose major version
+// differs is likely to be incompatible.
+const hostVersion = "1.0.0"
+
+// ExtensionInfo describes the connected extension, as announced in its
+// extension/hello notification.
+type ExtensionInfo struct {
+	Version          string   `json:"version"`
+	BrowserName      string   `json:"browserName"`
+	SupportedMethods []string `json:"supportedMethods"`
+}
+
+// ExtensionInfo returns the capabilities announced by the connected
+// extension, or nil if it has not sent extension/hello.
+func (s *Server) ExtensionInfo() *ExtensionInfo {
+	s.connMu.RLock()
+	defer s.connMu.RUnlock()
+	return s.extensionInfo
+}
+
+// extensionSupports reports whether the connected extension announced
+// method in its extension/hello.
+func (s *Server) extensionSupports(method string) bool {
+	info := s.ExtensionInfo()
+	return info != nil && slices.Contains(info.SupportedMethods, method)
+}
+
+// handleHello records the extension's extension/hello announcement.
+func (s *Server) handleHello(raw json.RawMessage) {
+	var info ExtensionInfo
+	if err := json.Unmarshal(raw, &info); err != nil {
+		s.logger.Error("invalid extension/hello", "error", err)
+		return
+	}
+
+	s.connMu.Lock()
+	s.extensionInfo = &info
+	s.connMu.Unlock()
+
+	s.logger.Info("extension hello", "version", info.Version, "browser", info.BrowserName, "methods", len(info.SupportedMethods))
+	if majorVersion(info.Version) != majorVersion(hostVersion) {
+		s.logger.Warn("extension major version differs from host", "extension", info.Version, "host", hostVersion)
+	}
+}
+
+// majorVersion returns the part of a dotted version before the first dot.
+func majorVersion(v string) string {
+	major, _, _ := strings.Cut(strings.TrimPrefix(v, "v"), ".")
+	return major
+}
@@ -3,15 +3,19 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/naqerl/browser-mcp-bridge/internal/logging"
 	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
 )
 
@@ -37,6 +41,113 @@ type Handler interface {
 	FillInput(ctx context.Context, tabID int, selector, value string) error
 	ScrollPage(ctx context.Context, tabID int, x, y int) error
 	FindElements(ctx context.Context, tabID int, selector string) (*mcp.FindResult, error)
+	GetAccessibilityTree(ctx context.Context, tabID int) (*mcp.AccessibilityNode, error)
+	EvaluateXPath(ctx context.Context, tabID int, expression string, contextSelector string) (*mcp.XPathResult, error)
+	ObserveMutations(ctx context.Context, tabID int, selector string, timeout time.Duration) ([]mcp.DomMutation, error)
+	ListDownloads(ctx context.Context, query string, limit int) ([]mcp.Download, error)
+	OpenDownload(ctx context.Context, downloadID int) error
+	CancelDownload(ctx context.Context, downloadID int) error
+	EraseDownload(ctx context.Context, downloadID int) error
+	GetBoundingRect(ctx context.Context, tabID int, selector string, includeScrollOffset bool) (*mcp.BoundingRect, error)
+	BatchTabOp(ctx context.Context, ops []mcp.TabOp) ([]mcp.TabOpResult, error)
+	ScrollToElement(ctx context.Context, tabID int, selector string, behavior string) error
+	SetCheckbox(ctx context.Context, tabID int, selector string, checked bool) error
+	FocusElement(ctx context.Context, tabID int, selector string) error
+	BlurElement(ctx context.Context, tabID int, selector string) error
+	HandleDialog(ctx context.Context, tabID int, action string, promptText string) error
+	GetPendingDialog(ctx context.Context, tabID int) (*mcp.PendingDialog, error)
+	ListBookmarks(ctx context.Context, query string) ([]mcp.Bookmark, error)
+	CreateBookmark(ctx context.Context, parentID, title, url string) (mcp.Bookmark, error)
+	DeleteBookmark(ctx context.Context, id string) error
+	ListIframes(ctx context.Context, tabID int) ([]mcp.IframeInfo, error)
+	GetIframeContent(ctx context.Context, tabID int, iframeSelector string) (*mcp.PageContent, error)
+	GetConsoleLogs(ctx context.Context, tabID int, level string, limit int) ([]mcp.ConsoleLog, error)
+	WaitForNavigation(ctx context.Context, tabID int, timeout time.Duration, waitUntil string) error
+	GetMetaTags(ctx context.Context, tabID int) ([]mcp.MetaTag, error)
+	GetOpenGraphTags(ctx context.Context, tabID int) (map[string]string, error)
+	MonitorNetworkRequests(ctx context.Context, tabID int, durationMs int, urlPattern string) ([]mcp.NetworkRequest, error)
+	MuteTab(ctx context.Context, tabID int, muted bool) error
+	PrintToPDF(ctx context.Context, tabID int, opts mcp.PDFOptions) (string, error)
+	ExecuteScriptInFrame(ctx context.Context, tabID int, frameSelector string, script string) (any, error)
+	HighlightElements(ctx context.Context, tabID int, selector string, color string, durationMs int) (int, error)
+	GetElementByLabel(ctx context.Context, tabID int, labelText string) (*mcp.ElementInfo, error)
+	FillByLabel(ctx context.Context, tabID int, labelText, value string) error
+	EmulateGeolocation(ctx context.Context, tabID int, latitude, longitude, accuracy float64) error
+	ClearGeolocation(ctx context.Context, tabID int) error
+	EmulateDevice(ctx context.Context, tabID int, device mcp.DeviceProfile) error
+	GetPageErrors(ctx context.Context, tabID int, clearAfterRead bool) ([]mcp.PageError, error)
+	InterceptResponse(ctx context.Context, tabID int, urlPattern string, timeoutMs int) (*mcp.InterceptedResponse, error)
+	ResolveTabID(ctx context.Context, tabURL string) (int, error)
+	GetTitle(ctx context.Context, tabID int) (string, error)
+	GetCurrentURL(ctx context.Context, tabID int) (string, error)
+	GetPageContentMarkdown(ctx context.Context, tabID int) (string, error)
+	RunSnippet(ctx context.Context, tabID int, snippetName string, args map[string]any) (any, error)
+	FilterTabs(ctx context.Context, filter mcp.TabFilter) ([]mcp.Tab, error)
+	GetStructuredData(ctx context.Context, tabID int) (*mcp.StructuredData, error)
+	ScreenshotElement(ctx context.Context, tabID int, selector string, opts mcp.ScreenshotOptions) (string, error)
+	EmulateTimezone(ctx context.Context, tabID int, timezone string) error
+	ClearInput(ctx context.Context, tabID int, selector string) error
+	GetPageSource(ctx context.Context, tabID int) (string, error)
+	WaitForURL(ctx context.Context, tabID int, pattern string, timeout time.Duration) (string, error)
+	ListStorageKeys(ctx context.Context, tabID int, storageType string) ([]string, error)
+	ClearStorage(ctx context.Context, tabID int, storageType string) error
+	ReloadTab(ctx context.Context, tabID int, bypassCache bool) error
+	GetSelection(ctx context.Context, tabID int) (string, error)
+	SetSelection(ctx context.Context, tabID int, selector string, start, end int) error
+	ClickAt(ctx context.Context, tabID int, x, y int, button string) error
+	GetElementText(ctx context.Context, tabID int, selector string) (string, error)
+	GetElementHTML(ctx context.Context, tabID int, selector string) (string, error)
+	WaitForText(ctx context.Context, tabID int, text string, present bool, timeout time.Duration) error
+	GetScrollPosition(ctx context.Context, tabID int) (x, y int, err error)
+	GetScrollSize(ctx context.Context, tabID int) (width, height int, err error)
+	EmulateMedia(ctx context.Context, tabID int, media string) error
+	CountElements(ctx context.Context, tabID int, selector string) (int, error)
+	ElementExists(ctx context.Context, tabID int, selector string) (bool, error)
+	GetFormFields(ctx context.Context, tabID int, formSelector string) ([]mcp.FormField, error)
+	ScrollBy(ctx context.Context, tabID int, deltaX, deltaY int, behavior string) error
+	ScrollElementBy(ctx context.Context, tabID int, selector string, deltaX, deltaY int) error
+	GetLinks(ctx context.Context, tabID int, filter mcp.LinkFilter) ([]mcp.Link, error)
+	GetImages(ctx context.Context, tabID int, loadedOnly, includeBase64 bool) ([]mcp.ImageInfo, error)
+	TriggerEvent(ctx context.Context, tabID int, selector string, eventType string, eventInit map[string]any) error
+	GetTableData(ctx context.Context, tabID int, selector string) (*mcp.TableData, error)
+	GetDropdownOptions(ctx context.Context, tabID int, selector string) ([]mcp.DropdownOption, error)
+	GetSelectedOption(ctx context.Context, tabID int, selector string) (*mcp.DropdownOption, error)
+	MoveTab(ctx context.Context, tabID int, windowID int, index int) error
+	MoveTabToNewWindow(ctx context.Context, tabID int) (mcp.Window, error)
+	FocusAndNavigate(ctx context.Context, tabID int, url string, waitForLoad bool) error
+	GetVideoInfo(ctx context.Context, tabID int, selector string) (*mcp.VideoInfo, error)
+	ControlVideo(ctx context.Context, tabID int, selector, action string) error
+	ExecuteAsyncScript(ctx context.Context, tabID int, script string, timeoutMs int) (any, error)
+	GetPerformanceMetrics(ctx context.Context, tabID int) (*mcp.PerformanceMetrics, error)
+	RightClick(ctx context.Context, tabID int, selector string) error
+	DoubleClick(ctx context.Context, tabID int, selector string) error
+	GetCanvasData(ctx context.Context, tabID int, selector string, format string) (string, error)
+	EmulateColorScheme(ctx context.Context, tabID int, scheme string) error
+	ActiveEmulations() map[int]map[string]string
+	ClearEmulations(tabID int, kinds ...string)
+	ClearAllEmulations()
+	CloseMultipleTabs(ctx context.Context, tabIDs []int) error
+	CloseOtherTabs(ctx context.Context, keepTabID int) (int, error)
+	GetAnchorByText(ctx context.Context, tabID int, text string, exact bool) (*mcp.Link, error)
+	ClickAnchorByText(ctx context.Context, tabID int, text string, exact bool) error
+	SetAttribute(ctx context.Context, tabID int, selector, attribute, value string) error
+	RemoveAttribute(ctx context.Context, tabID int, selector, attribute string) error
+	GetTextNodes(ctx context.Context, tabID int, minLength int) ([]string, error)
+	GetVisibleText(ctx context.Context, tabID int) (string, error)
+	RunLighthouse(ctx context.Context, tabID int, categories []string) (*mcp.LighthouseResult, error)
+	GetColorAt(ctx context.Context, tabID int, x, y int) (*mcp.Color, error)
+	GetShadowRoot(ctx context.Context, tabID int, hostSelector string) (*mcp.PageContent, error)
+	QueryShadowRoot(ctx context.Context, tabID int, hostSelector, innerSelector string) ([]mcp.ElementInfo, error)
+	MockGeolocationTrack(ctx context.Context, tabID int, positions []mcp.GeoPosition, intervalMs int) error
+	ExecuteInWorker(ctx context.Context, tabID int, script string, timeoutMs int) (any, error)
+	GetRobotsTxt(ctx context.Context, tabID int) (string, error)
+	CheckRobotsAllowed(ctx context.Context, tabID int, path, userAgent string) (bool, error)
+	GetSitemap(ctx context.Context, tabID int) ([]mcp.SitemapEntry, error)
+	DiffScreenshots(ctx context.Context, tabID int, baselineBase64 string, threshold float64) (*mcp.ScreenshotDiff, error)
+	GetWebWorkers(ctx context.Context, tabID int) ([]mcp.WorkerInfo, error)
+	GetResponseHeaders(ctx context.Context, tabID int) (map[string]string, error)
+	WaitForNetworkIdle(ctx context.Context, tabID int, idleTime, timeout time.Duration) error
+	GetStorageQuota(ctx context.Context, tabID int) (*mcp.StorageQuota, error)
 	GetTools() []mcp.Tool
 }
 
@@ -47,19 +158,171 @@ type Server struct {
 	server      *http.Server
 	conn        *websocket.Conn
 	connMu      sync.RWMutex
+	writeMu     sync.Mutex // serializes writes; the connection allows one writer
 	requestMu   sync.Mutex
-	pendingReqs map[int]chan *mcp.Message
+	pendingReqs map[mcp.IDValue]*pendingRequest
+	inflight    map[string]*inflightCall
+	dedup       bool
 	reqID       int
 	logger      *slog.Logger
+	token       []byte
+	tlsCert     string
+	tlsKey      string
+	bindAddress string
+	metrics     *metrics
+
+	protocolVersions []string
+
+	// drainTimeout bounds how long Stop waits for in-flight requests.
+	drainTimeout time.Duration
+	draining     bool
+
+	// keepaliveInterval is how often a WebSocket ping is sent; a connection
+	// that is silent for twice this long is considered dead.
+	keepaliveInterval time.Duration
+
+	notifications NotificationHandler
+	connObserver  ConnectionObserver
+	extensionInfo *ExtensionInfo
+
+	// pendingLimit caps pendingReqs; overloaded records that the cap was
+	// hit so the warning is logged once per crossing.
+	pendingLimit int
+	overloaded   bool
+
+	// tools maps MCP tool names to their handlers; see registerTools.
+	tools map[string]toolHandler
+}
+
+// pendingRequest is a request awaiting a response on the connection it was
+// sent on.
+type pendingRequest struct {
+	conn *websocket.Conn
+	ch   chan *mcp.Message
+}
+
+// ErrOverloaded is returned for requests rejected because the number of
+// pending extension requests reached the limit set with WithPendingLimit.
+var ErrOverloaded = errors.New("service overloaded")
+
+// defaultDrainTimeout is how long Stop waits for in-flight requests to the
+// extension to complete before closing the connection.
+const defaultDrainTimeout = 5 * time.Second
+
+// shutdownGrace is how long Stop waits for HTTP requests to finish once
+// the extension connection is closed.
+const shutdownGrace = 5 * time.Second
+
+// defaultBindAddress keeps the server reachable from this machine only.
+const defaultBindAddress = "127.0.0.1"
+
+// defaultKeepaliveInterval is how often WebSocket pings are sent.
+const defaultKeepaliveInterval = 30 * time.Second
+
+// inflightCall is a SendRequest round-trip shared by deduplicated callers.
+type inflightCall struct {
+	done chan struct{}
+	resp *mcp.Message
+	err  error
+}
+
+// Option configures optional Server behaviour.
+type Option func(*Server)
+
+// WithToken requires clients to authenticate with the given shared secret.
+// An empty token disables authentication.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		if token != "" {
+			s.token = []byte(token)
+		}
+	}
+}
+
+// WithTLS serves HTTPS/WSS using the given certificate and key files.
+func WithTLS(certFile, keyFile string) Option {
+	return func(s *Server) {
+		s.tlsCert = certFile
+		s.tlsKey = keyFile
+	}
+}
+
+// WithBindAddress sets the interface address the server listens on
+// (default 127.0.0.1, reachable from this machine only). An empty address
+// keeps the default.
+func WithBindAddress(addr string) Option {
+	return func(s *Server) {
+		if addr != "" {
+			s.bindAddress = addr
+		}
+	}
+}
+
+// WithMetrics enables or disables the /metrics endpoint (enabled by default).
+func WithMetrics(enabled bool) Option {
+	return func(s *Server) {
+		if !enabled {
+			s.metrics = nil
+		}
+	}
+}
+
+// WithDedup makes concurrent SendRequest calls with identical method and
+// params share one in-flight request. Only enable this when the requests
+// sent to the extension are idempotent.
+func WithDedup(enabled bool) Option {
+	return func(s *Server) {
+		s.dedup = enabled
+	}
+}
+
+// WithDrainTimeout sets how long Stop waits for in-flight requests to the
+// extension to complete before closing the connection (default 5s).
+func WithDrainTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		s.drainTimeout = d
+	}
+}
+
+// WithPendingLimit rejects new requests to the extension with ErrOverloaded
+// while n requests are already waiting for a response. Zero (the default)
+// means no limit.
+func WithPendingLimit(n int) Option {
+	return func(s *Server) {
+		s.pendingLimit = n
+	}
+}
+
+// WithKeepaliveInterval sets how often a WebSocket ping is sent to the
+// extension (default 30s). Connections that stay silent for twice the
+// interval are closed.
+func WithKeepaliveInterval(d time.Duration) Option {
+	return func(s *Server) {
+		if d > 0 {
+			s.keepaliveInterval = d
+		}
+	}
 }
 
 // New creates a new WebSocket server.
-func New(handler Handler, logger *slog.Logger) *Server {
-	return &Server{
+func New(handler Handler, logger *slog.Logger, opts ...Option) *Server {
+	s := &Server{
+		bindAddress: defaultBindAddress,
 		handler:     handler,
-		pendingReqs: make(map[int]chan *mcp.Message),
+		pendingReqs: make(map[mcp.IDValue]*pendingRequest),
+		inflight:    make(map[string]*inflightCall),
 		logger:      logger,
+		metrics:     newMetrics(),
+
+		protocolVersions: defaultProtocolVersions,
+		drainTimeout:     defaultDrainTimeout,
+
+		keepaliveInterval: defaultKeepaliveInterval,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // Start starts the WebSocket server on an ephemeral port.
@@ -71,7 +334,7 @@ func (s *Server) Start() (int, error) {
 // StartFixed starts the WebSocket server on a specific port (or 0 for ephemeral).
 // Returns the actual port number and any error.
 func (s *Server) StartFixed(port int) (int, error) {
-	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	addr := net.JoinHostPort(s.bindAddress, strconv.Itoa(port))
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
 		return 0, fmt.Errorf("failed to listen on %s: %w", addr, err)
@@ -81,8 +344,11 @@ func (s *Server) StartFixed(port int) (int, error) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/ws", s.handleWebSocket)
 	mux.HandleFunc("/health", s.handleHealth)
+	if s.metrics != nil {
+		mux.HandleFunc("/metrics", s.handleMetrics)
+	}
 	
-	// MCP 2024-11-05 protocol - root endpoint for initialization
+	// MCP protocol - root endpoint for initialization
 	mux.HandleFunc("/", s.handleMCPRoot)
 	
 	// Add HTTP MCP endpoints
@@ -92,13 +358,31 @@ func (s *Server) StartFixed(port int) (int, error) {
 	s.setupSSERoutes(mux)
 
 	s.server = &http.Server{
-		Handler:      corsMiddleware(mux),
+		Handler:      corsMiddleware(s.authMiddleware(mux)),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 	}
 
+	if s.TLSEnabled() {
+		cert, err := tls.LoadX509KeyPair(s.tlsCert, s.tlsKey)
+		if err != nil {
+			listener.Close()
+			return 0, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		s.server.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
 	go func() {
-		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		var err error
+		if s.TLSEnabled() {
+			err = s.server.ServeTLS(listener, "", "")
+		} else {
+			err = s.server.Serve(listener)
+		}
+		if err != nil && err != http.ErrServerClosed {
 			s.logger.Error("server error", "error", err)
 		}
 	}()
@@ -106,12 +390,71 @@ func (s *Server) StartFixed(port int) (int, error) {
 	return listener.Addr().(*net.TCPAddr).Port, nil
 }
 
-// Stop stops the server.
+// TLSEnabled returns true if the server is configured to serve HTTPS/WSS.
+func (s *Server) TLSEnabled() bool {
+	return s.tlsCert != "" && s.tlsKey != ""
+}
+
+// Stop stops the server. New requests to the extension are rejected, and
+// in-flight ones are given up to the drain timeout, or until ctx is done, to
+// complete before the connection is closed. HTTP requests then get another
+// 5 seconds to finish, even if the drain used up ctx.
 func (s *Server) Stop(ctx context.Context) error {
-	if s.conn != nil {
-		s.conn.Close()
+	s.drain(ctx)
+
+	s.connMu.RLock()
+	conn := s.conn
+	s.connMu.RUnlock()
+	if conn != nil {
+		conn.Close()
 	}
-	return s.server.Shutdown(ctx)
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownGrace)
+	defer cancel()
+	return s.server.Shutdown(shutdownCtx)
+}
+
+// drain stops accepting new requests and waits until all pending requests
+// have been resolved, the drain timeout expires or ctx is done.
+func (s *Server) drain(ctx context.Context) {
+	s.requestMu.Lock()
+	s.draining = true
+	pending := len(s.pendingReqs)
+	s.requestMu.Unlock()
+
+	if pending == 0 {
+		return
+	}
+
+	deadline := time.NewTimer(s.drainTimeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	remaining := pending
+wait:
+	for remaining > 0 {
+		select {
+		case <-ctx.Done():
+			break wait
+		case <-deadline.C:
+			break wait
+		case <-ticker.C:
+			s.requestMu.Lock()
+			remaining = len(s.pendingReqs)
+			s.requestMu.Unlock()
+		}
+	}
+
+	s.logger.Info("drained pending requests", "drained", pending-remaining, "abandoned", remaining)
+}
+
+// PendingCount returns the number of requests waiting for a response from
+// the extension.
+func (s *Server) PendingCount() int {
+	s.requestMu.Lock()
+	defer s.requestMu.Unlock()
+	return len(s.pendingReqs)
 }
 
 // IsConnected returns true if a WebSocket client is connected.
@@ -126,11 +469,13 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	response := map[string]any{
 		"status":              "ok",
 		"extension_connected": s.IsConnected(),
+		"pending_requests":    s.PendingCount(),
+		"emulations":          s.handler.ActiveEmulations(),
 	}
 	json.NewEncoder(w).Encode(response)
 }
 
-// handleMCPRoot handles the root endpoint for MCP 2024-11-05 protocol
+// handleMCPRoot handles the root endpoint for the MCP protocol
 func (s *Server) handleMCPRoot(w http.ResponseWriter, r *http.Request) {
 	if r.URL.Path != "/" {
 		http.NotFound(w, r)
@@ -142,9 +487,10 @@ func (s *Server) handleMCPRoot(w http.ResponseWriter, r *http.Request) {
 		// Return server info
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]any{
-			"name":             "browser-mcp",
-			"version":          "1.0.0",
-			"protocol_version": "2024-11-05",
+			"name":                        "browser-mcp",
+			"version":                     "1.0.0",
+			"protocol_version":            s.latestProtocolVersion(),
+			"supported_protocol_versions": s.sortedProtocolVersions(),
 		})
 		return
 	}
@@ -173,23 +519,32 @@ func (s *Server) handleMCPRoot(w http.ResponseWriter, r *http.Request) {
 
 	switch req.Method {
 	case "initialize":
-		result = map[string]any{
-			"protocolVersion": "2024-11-05",
-			"capabilities":    map[string]any{},
-			"serverInfo": map[string]any{
-				"name":    "browser-mcp",
-				"version": "1.0.0",
-			},
+		var initReq struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		if len(req.Params) > 0 {
+			json.Unmarshal(req.Params, &initReq)
+		}
+		var version string
+		if version, err = s.negotiateProtocolVersion(initReq.ProtocolVersion); err == nil {
+			result = map[string]any{
+				"protocolVersion": version,
+				"capabilities":    map[string]any{},
+				"serverInfo": map[string]any{
+					"name":    "browser-mcp",
+					"version": "1.0.0",
+				},
+			}
 		}
 	case "tools/list":
-		result = map[string]any{"tools": mcp.GetTools()}
+		result = map[string]any{"tools": s.toolList()}
 	case "tools/call":
 		var toolReq struct {
 			Name string          `json:"name"`
 			Args json.RawMessage `json:"arguments"`
 		}
 		if err = json.Unmarshal(req.Params, &toolReq); err == nil {
-			result, err = s.callTool(toolReq.Name, toolReq.Args)
+			result, err = s.callTool(s.requestContext(r), toolReq.Name, toolReq.Args)
 		}
 	default:
 		err = fmt.Errorf("unknown method: %s", req.Method)
@@ -201,7 +556,17 @@ func (s *Server) handleMCPRoot(w http.ResponseWriter, r *http.Request) {
 		"id":      req.ID,
 	}
 
-	if err != nil {
+	var mcpErr *mcp.Error
+	if errors.As(err, &mcpErr) {
+		rpcErr := map[string]any{
+			"code":    mcpErr.Code,
+			"message": mcpErr.Message,
+		}
+		if mcpErr.Data != nil {
+			rpcErr["data"] = mcpErr.Data
+		}
+		response["error"] = rpcErr
+	} else if err != nil {
 		response["error"] = map[string]any{
 			"code":    -32603,
 			"message": err.Error(),
@@ -211,14 +576,17 @@ func (s *Server) handleMCPRoot(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if errors.Is(err, ErrOverloaded) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
 	json.NewEncoder(w).Encode(response)
 }
 
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -240,25 +608,59 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	s.conn = conn
 	s.connMu.Unlock()
 
-	s.logger.Info("client connected", "remote", r.RemoteAddr)
+	sessionID := generateSessionID()
+	s.logger.Info("client connected", "remote", r.RemoteAddr, "session", sessionID)
+	if s.connObserver != nil {
+		s.connObserver.OnConnect(sessionID)
+	}
 
+	reason := "error"
+	stopKeepalive := make(chan struct{})
 	defer func() {
+		close(stopKeepalive)
+		if s.connObserver != nil {
+			s.connObserver.OnDisconnect(sessionID, reason)
+		}
+		// A newer connection may already have replaced this one
 		s.connMu.Lock()
-		s.conn = nil
+		current := s.conn == conn
+		if current {
+			s.conn = nil
+			s.extensionInfo = nil
+		}
 		s.connMu.Unlock()
+		if current && s.handler != nil {
+			s.handler.ClearAllEmulations()
+		}
 		conn.Close()
-		s.logger.Info("client disconnected")
+		s.logger.Info("client disconnected", "reason", reason)
+		s.failPending(conn)
 	}()
 
+	// Any message, including a pong, proves the connection is alive.
+	deadline := 2 * s.keepaliveInterval
+	conn.SetReadDeadline(time.Now().Add(deadline))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(deadline))
+	})
+	go s.keepalive(conn, stopKeepalive)
+
 	// Read loop
 	for {
 		_, data, err := conn.ReadMessage()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+			var netErr net.Error
+			switch {
+			case errors.As(err, &netErr) && netErr.Timeout():
+				reason = "keepalive_timeout"
+			case websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway):
+				reason = "clean_close"
+			case websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure):
 				s.logger.Error("websocket read error", "error", err)
 			}
 			return
 		}
+		conn.SetReadDeadline(time.Now().Add(deadline))
 
 		var msg mcp.Message
 		if err := json.Unmarshal(data, &msg); err != nil {
@@ -267,23 +669,65 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Handle response to pending request
-		if msg.ID != 0 && (msg.Result != nil || msg.Error != nil) {
+		if msg.ID.IsSet() && (msg.Result != nil || msg.Error != nil) {
 			s.requestMu.Lock()
-			ch, ok := s.pendingReqs[msg.ID]
+			req, ok := s.pendingReqs[msg.ID]
 			s.requestMu.Unlock()
-			if ok {
-				ch <- &msg
+			if ok && req.conn == conn {
+				req.ch <- &msg
 				continue
 			}
 		}
 
+		// Messages without an id are notifications and get no response
+		if !msg.ID.IsSet() {
+			go s.handleNotification(&msg)
+			continue
+		}
+
 		// Handle incoming request
 		go s.handleRequest(&msg)
 	}
 }
 
+// failPending resolves every in-flight request sent on conn with an
+// ErrDisconnected error so callers fail immediately instead of waiting for
+// the timeout. Requests sent on other connections are left alone.
+func (s *Server) failPending(conn *websocket.Conn) {
+	s.requestMu.Lock()
+	defer s.requestMu.Unlock()
+
+	for id, req := range s.pendingReqs {
+		if req.conn != conn {
+			continue
+		}
+		select {
+		case req.ch <- mcp.ErrorResponse(id, mcp.ErrDisconnected, "extension disconnected"):
+		default:
+		}
+	}
+}
+
+// keepalive sends WebSocket pings on conn until stop is closed.
+func (s *Server) keepalive(conn *websocket.Conn, stop <-chan struct{}) {
+	ticker := time.NewTicker(s.keepaliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				s.logger.Debug("keepalive ping failed", "error", err)
+				return
+			}
+		}
+	}
+}
+
 func (s *Server) handleRequest(msg *mcp.Message) {
-	ctx := context.Background()
+	ctx := logging.ContextWithLogger(context.Background(), s.logger.With("correlation_id", msg.ID.String()))
 	var result any
 	var err error
 
@@ -335,7 +779,11 @@ func (s *Server) handleRequest(msg *mcp.Message) {
 	case "page/scroll":
 		var params mcp.ScrollPageParams
 		if err = json.Unmarshal(msg.Params, &params); err == nil {
-			err = s.handler.ScrollPage(ctx, params.TabID, params.X, params.Y)
+			if params.Selector != "" {
+				err = s.handler.ScrollToElement(ctx, params.TabID, params.Selector, params.Behavior)
+			} else {
+				err = s.handler.ScrollPage(ctx, params.TabID, params.X, params.Y)
+			}
 		}
 	case "page/find":
 		var params mcp.FindElementParams
@@ -343,23 +791,13 @@ func (s *Server) handleRequest(msg *mcp.Message) {
 			result, err = s.handler.FindElements(ctx, params.TabID, params.Selector)
 		}
 	case "mcp/tools":
-		result = mcp.GetTools()
+		result = s.toolList()
 	case "ping":
 		// Keepalive ping - just respond with pong
 		result = map[string]any{"pong": true}
 	case "extension/error":
 		// Log extension errors for debugging
-		var params struct {
-			Message string `json:"message"`
-			Stack   string `json:"stack"`
-			Context string `json:"context"`
-			Time    int64  `json:"time"`
-		}
-		if err = json.Unmarshal(msg.Params, &params); err == nil {
-			s.logger.Error("extension error", 
-				"context", params.Context, 
-				"message", params.Message, 
-				"stack", params.Stack)
+		if err = s.logExtensionError(msg.Params); err == nil {
 			result = map[string]any{"logged": true}
 		}
 	default:
@@ -385,40 +823,93 @@ func (s *Server) sendMessage(msg *mcp.Message) error {
 	if conn == nil {
 		return fmt.Errorf("not connected")
 	}
+	return s.writeMessage(conn, msg)
+}
 
+// writeMessage writes msg to conn.
+func (s *Server) writeMessage(conn *websocket.Conn, msg *mcp.Message) error {
 	data, err := json.Marshal(msg)
 	if err != nil {
 		return err
 	}
 
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
 	return conn.WriteMessage(websocket.TextMessage, data)
 }
 
 // SendRequest sends a request to the browser extension and waits for response.
 // This is used when the Go host needs to initiate communication.
+// With deduplication enabled, concurrent calls with the same method and
+// params share a single round-trip to the extension.
 func (s *Server) SendRequest(method string, params any) (*mcp.Message, error) {
+	paramsData, _ := json.Marshal(params)
+	if !s.dedup {
+		return s.roundTrip(method, paramsData)
+	}
+
+	key := method + "\x00" + string(paramsData)
+	s.requestMu.Lock()
+	if call, ok := s.inflight[key]; ok {
+		s.requestMu.Unlock()
+		s.logger.Debug("SendRequest deduplicated", "method", method)
+		<-call.done
+		return call.resp, call.err
+	}
+	call := &inflightCall{done: make(chan struct{})}
+	s.inflight[key] = call
+	s.requestMu.Unlock()
+
+	call.resp, call.err = s.roundTrip(method, paramsData)
+
+	s.requestMu.Lock()
+	delete(s.inflight, key)
+	s.requestMu.Unlock()
+	close(call.done)
+
+	return call.resp, call.err
+}
+
+// roundTrip sends a single request to the extension and waits for its response.
+func (s *Server) roundTrip(method string, paramsData json.RawMessage) (*mcp.Message, error) {
 	s.connMu.RLock()
 	conn := s.conn
 	s.connMu.RUnlock()
 
 	if conn == nil {
-		return nil, fmt.Errorf("not connected")
+		return nil, fmt.Errorf("not connected: %w", mcp.ErrNotSent)
 	}
 
 	s.requestMu.Lock()
+	if s.draining {
+		s.requestMu.Unlock()
+		return nil, fmt.Errorf("server is shutting down")
+	}
+	if s.pendingLimit > 0 && len(s.pendingReqs) >= s.pendingLimit {
+		if !s.overloaded {
+			s.overloaded = true
+			s.logger.Warn("pending request limit reached, rejecting new requests",
+				"pending", len(s.pendingReqs), "limit", s.pendingLimit)
+		}
+		s.requestMu.Unlock()
+		return nil, ErrOverloaded
+	}
 	s.reqID += 1000  // Use large increments to avoid collision with extension IDs
-	id := s.reqID
+	id := mcp.NumberID(int64(s.reqID))
 	ch := make(chan *mcp.Message, 1)
-	s.pendingReqs[id] = ch
+	s.pendingReqs[id] = &pendingRequest{conn: conn, ch: ch}
 	s.requestMu.Unlock()
 
 	defer func() {
 		s.requestMu.Lock()
 		delete(s.pendingReqs, id)
+		if s.overloaded && len(s.pendingReqs) < s.pendingLimit {
+			s.overloaded = false
+			s.logger.Info("pending requests back under limit", "pending", len(s.pendingReqs), "limit", s.pendingLimit)
+		}
 		s.requestMu.Unlock()
 	}()
 
-	paramsData, _ := json.Marshal(params)
 	s.logger.Debug("SendRequest", "method", method, "params", string(paramsData))
 	msg := &mcp.Message{
 		ID:     id,
@@ -426,8 +917,8 @@ func (s *Server) SendRequest(method string, params any) (*mcp.Message, error) {
 		Params: paramsData,
 	}
 
-	if err := s.sendMessage(msg); err != nil {
-		return nil, err
+	if err := s.writeMessage(conn, msg); err != nil {
+		return nil, fmt.Errorf("%w: %v", mcp.ErrNotSent, err)
 	}
 
 	select {
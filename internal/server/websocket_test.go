@@ -0,0 +1,257 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/naqerl/browser-mcp-bridge/internal/mcp"
+)
+
+func newTestServer(opts ...Option) *Server {
+	return New(nil, slog.New(slog.NewTextHandler(io.Discard, nil)), opts...)
+}
+
+// toolsHandler is a Handler that advertises the standard tools, enough for
+// the server to start; calling any other method panics.
+type toolsHandler struct {
+	Handler
+}
+
+func (toolsHandler) GetTools() []mcp.Tool { return mcp.GetTools() }
+func (toolsHandler) ClearAllEmulations()  {}
+
+// connectExtension starts s's WebSocket endpoint and connects a fake
+// extension that answers every request with respond. It returns once the
+// server has registered the connection.
+func connectExtension(t *testing.T, s *Server, respond func(*mcp.Message) *mcp.Message) *websocket.Conn {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(s.handleWebSocket))
+	t.Cleanup(ts.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		var writeMu sync.Mutex
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg mcp.Message
+			if err := json.Unmarshal(data, &msg); err != nil {
+				continue
+			}
+			go func() {
+				resp := respond(&msg)
+				if resp == nil {
+					return
+				}
+				out, _ := json.Marshal(resp)
+				writeMu.Lock()
+				defer writeMu.Unlock()
+				conn.WriteMessage(websocket.TextMessage, out)
+			}()
+		}
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !s.IsConnected() {
+		if time.Now().After(deadline) {
+			t.Fatal("server did not register the connection")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return conn
+}
+
+func echoMethod(msg *mcp.Message) *mcp.Message {
+	return mcp.SuccessResponse(msg.ID, msg.Method)
+}
+
+func TestSendRequestConcurrent(t *testing.T) {
+	s := newTestServer()
+	connectExtension(t, s, echoMethod)
+
+	const n = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			method := fmt.Sprintf("test.method%d", i)
+			resp, err := s.SendRequest(method, map[string]any{"i": i})
+			if err != nil {
+				errs <- err
+				return
+			}
+			var got string
+			if err := json.Unmarshal(resp.Result, &got); err != nil || got != method {
+				errs <- fmt.Errorf("request %d: got result %s", i, resp.Result)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestDisconnectFailsOnlyItsOwnRequests(t *testing.T) {
+	s := newTestServer()
+	old := connectExtension(t, s, func(*mcp.Message) *mcp.Message { return nil })
+
+	oldResp := make(chan *mcp.Message, 1)
+	go func() {
+		resp, _ := s.SendRequest("test.old", nil)
+		oldResp <- resp
+	}()
+	waitFor(t, func() bool { return s.PendingCount() == 1 })
+
+	// A second extension replaces the first before it goes away
+	s.connMu.RLock()
+	first := s.conn
+	s.connMu.RUnlock()
+	release := make(chan struct{})
+	connectExtension(t, s, func(msg *mcp.Message) *mcp.Message {
+		<-release
+		return echoMethod(msg)
+	})
+	waitFor(t, func() bool {
+		s.connMu.RLock()
+		defer s.connMu.RUnlock()
+		return s.conn != first
+	})
+
+	newResp := make(chan *mcp.Message, 1)
+	go func() {
+		resp, _ := s.SendRequest("test.new", nil)
+		newResp <- resp
+	}()
+	waitFor(t, func() bool { return s.PendingCount() == 2 })
+
+	old.Close()
+	select {
+	case resp := <-oldResp:
+		if resp == nil || resp.Error == nil || resp.Error.Code != mcp.ErrDisconnected {
+			t.Fatalf("old request resolved with %+v, want ErrDisconnected", resp)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("old request was not failed on disconnect")
+	}
+	if !s.IsConnected() {
+		t.Fatal("closing the old connection dropped the new one")
+	}
+
+	close(release)
+	select {
+	case resp := <-newResp:
+		if resp == nil || resp.Error != nil {
+			t.Fatalf("new request resolved with %+v, want success", resp)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("new request did not complete")
+	}
+}
+
+// waitFor polls cond until it holds, failing the test after 2 seconds.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWithBindAddress(t *testing.T) {
+	for _, tt := range []struct {
+		addr string
+		want string
+	}{
+		{"", "127.0.0.1"},
+		{"::1", "::1"},
+	} {
+		t.Run(tt.want, func(t *testing.T) {
+			s := New(toolsHandler{}, slog.New(slog.NewTextHandler(io.Discard, nil)), WithBindAddress(tt.addr))
+			if _, err := s.Start(); err != nil {
+				t.Skipf("cannot listen on %s: %v", tt.want, err)
+			}
+			defer s.Stop(context.Background())
+			if host, _, _ := net.SplitHostPort(s.listener.Addr().String()); host != tt.want {
+				t.Errorf("WithBindAddress(%q): listening on %s, want %s", tt.addr, host, tt.want)
+			}
+		})
+	}
+}
+
+// sendingHandler is a Handler whose ListTabs forwards to the extension
+// connected to s and takes another 50ms to answer; calling any other method
+// panics.
+type sendingHandler struct {
+	toolsHandler
+	s *Server
+}
+
+func (h *sendingHandler) ListTabs(ctx context.Context) ([]mcp.Tab, error) {
+	_, err := h.s.SendRequest("browser.tabs.query", nil)
+	time.Sleep(50 * time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	return []mcp.Tab{}, nil
+}
+
+func TestStopAfterDrainTimeout(t *testing.T) {
+	h := &sendingHandler{}
+	s := New(h, slog.New(slog.NewTextHandler(io.Discard, nil)), WithDrainTimeout(100*time.Millisecond))
+	h.s = s
+	port, err := s.Start()
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	// An extension that never answers
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://127.0.0.1:%d/ws", port), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	waitFor(t, s.IsConnected)
+
+	go http.Get(fmt.Sprintf("http://127.0.0.1:%d/tabs", port))
+	waitFor(t, func() bool { return s.PendingCount() == 1 })
+
+	// The caller's deadline expires with the drain, leaving nothing for
+	// the HTTP request that is still being answered
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := s.Stop(ctx); err != nil {
+		t.Errorf("Stop = %v, want nil", err)
+	}
+}
@@ -0,0 +1,192 @@
+// Package client provides a Go client for the bridge's HTTP MCP endpoints.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client calls MCP tools on a running bridge via POST /mcp/call/{tool}.
+type Client struct {
+	baseURL string
+	token   string
+
+	// HTTPClient is used for requests; it defaults to a client with a
+	// 60 second timeout.
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client for the bridge at baseURL, such as
+// "http://localhost:6277". token is sent as a Bearer token when non-empty.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// toolResult is the MCP tool result wrapped by /mcp/call responses.
+type toolResult struct {
+	Result struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	} `json:"result"`
+}
+
+// CallText calls tool with args and returns the text of its result.
+func (c *Client) CallText(ctx context.Context, tool string, args any) (string, error) {
+	if args == nil {
+		args = struct{}{}
+	}
+	body, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s arguments: %w", tool, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/mcp/call/"+tool, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &e) == nil && e.Error != "" {
+			return "", fmt.Errorf("%s: %s", tool, e.Error)
+		}
+		return "", fmt.Errorf("%s: %s", tool, resp.Status)
+	}
+
+	var result toolResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal %s response: %w", tool, err)
+	}
+	if len(result.Result.Content) == 0 {
+		return "", nil
+	}
+	return result.Result.Content[0].Text, nil
+}
+
+// Call calls tool with args and decodes its JSON result into out, which
+// may be nil for tools that only report success.
+func (c *Client) Call(ctx context.Context, tool string, args any, out any) error {
+	text, err := c.CallText(ctx, tool, args)
+	if err != nil || out == nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(text), out); err != nil {
+		return fmt.Errorf("failed to unmarshal %s result: %w", tool, err)
+	}
+	return nil
+}
+
+// tabArgs are the arguments of tools that only take a tab.
+type tabArgs struct {
+	TabID int `json:"tabId"`
+}
+
+// ListTabs returns all open tabs.
+func (c *Client) ListTabs(ctx context.Context) ([]Tab, error) {
+	var tabs []Tab
+	err := c.Call(ctx, "browser_tabs_list", nil, &tabs)
+	return tabs, err
+}
+
+// ActivateTab activates a tab.
+func (c *Client) ActivateTab(ctx context.Context, tabID int) error {
+	return c.Call(ctx, "browser_tab_activate", tabArgs{tabID}, nil)
+}
+
+// NavigateTab navigates a tab to a URL.
+func (c *Client) NavigateTab(ctx context.Context, tabID int, url string) error {
+	return c.Call(ctx, "browser_tab_navigate", map[string]any{"tabId": tabID, "url": url}, nil)
+}
+
+// ReloadTab reloads a tab, optionally bypassing the cache.
+func (c *Client) ReloadTab(ctx context.Context, tabID int, bypassCache bool) error {
+	return c.Call(ctx, "browser_tab_reload", map[string]any{"tabId": tabID, "bypassCache": bypassCache}, nil)
+}
+
+// CloseTab closes a tab.
+func (c *Client) CloseTab(ctx context.Context, tabID int) error {
+	return c.Call(ctx, "browser_tab_close", tabArgs{tabID}, nil)
+}
+
+// ScreenshotTab returns a screenshot of a tab as a data URL.
+func (c *Client) ScreenshotTab(ctx context.Context, tabID int) (string, error) {
+	return c.CallText(ctx, "browser_tab_screenshot", tabArgs{tabID})
+}
+
+// GetPageContent returns the title, URL, text, HTML and links of a page.
+func (c *Client) GetPageContent(ctx context.Context, tabID int) (*PageContent, error) {
+	var content PageContent
+	if err := c.Call(ctx, "browser_page_content", tabArgs{tabID}, &content); err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// ClickElement clicks the element matched by selector.
+func (c *Client) ClickElement(ctx context.Context, tabID int, selector string) error {
+	return c.Call(ctx, "browser_page_click", map[string]any{"tabId": tabID, "selector": selector}, nil)
+}
+
+// FillInput sets the value of the input matched by selector.
+func (c *Client) FillInput(ctx context.Context, tabID int, selector, value string) error {
+	return c.Call(ctx, "browser_page_fill", map[string]any{"tabId": tabID, "selector": selector, "value": value}, nil)
+}
+
+// ScrollPage scrolls the page to x, y.
+func (c *Client) ScrollPage(ctx context.Context, tabID int, x, y int) error {
+	return c.Call(ctx, "browser_page_scroll", map[string]any{"tabId": tabID, "x": x, "y": y}, nil)
+}
+
+// ExecuteScript runs JavaScript in a tab and returns its result.
+func (c *Client) ExecuteScript(ctx context.Context, tabID int, script string) (any, error) {
+	var result any
+	err := c.Call(ctx, "browser_page_execute", map[string]any{"tabId": tabID, "script": script}, &result)
+	return result, err
+}
+
+// FindElements returns the elements matched by selector.
+func (c *Client) FindElements(ctx context.Context, tabID int, selector string) (*FindResult, error) {
+	var result FindResult
+	if err := c.Call(ctx, "browser_page_find", map[string]any{"tabId": tabID, "selector": selector}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WaitForNavigation waits until the page reaches waitUntil ("load",
+// "DOMContentLoaded" or "networkidle").
+func (c *Client) WaitForNavigation(ctx context.Context, tabID int, timeout time.Duration, waitUntil string) error {
+	return c.Call(ctx, "browser_page_wait_for_navigation", map[string]any{
+		"tabId":     tabID,
+		"waitUntil": waitUntil,
+		"timeoutMs": timeout.Milliseconds(),
+	}, nil)
+}
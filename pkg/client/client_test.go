@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// toolServer serves /mcp/call/{tool} like the bridge, answering each tool
+// with the JSON text in results and recording the arguments it was called
+// with.
+func toolServer(t *testing.T, token string, results map[string]string) (*httptest.Server, map[string]map[string]any) {
+	t.Helper()
+	calls := make(map[string]map[string]any)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		tool := strings.TrimPrefix(r.URL.Path, "/mcp/call/")
+		body, _ := io.ReadAll(r.Body)
+		var args map[string]any
+		json.Unmarshal(body, &args)
+		calls[tool] = args
+
+		text, ok := results[tool]
+		if !ok {
+			http.Error(w, `{"error": "Unknown tool: `+tool+`"}`, http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"result": map[string]any{"content": []map[string]any{{"type": "text", "text": text}}},
+		})
+	}))
+	t.Cleanup(ts.Close)
+	return ts, calls
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	ts, calls := toolServer(t, "secret", map[string]string{
+		"browser_tabs_list":      `[{"id": 1, "url": "https://example.com", "active": true, "mutedInfo": {"muted": true, "reason": "user"}}]`,
+		"browser_page_content":   `{"title": "Example", "url": "https://example.com", "links": [{"text": "More", "href": "https://example.com/more"}]}`,
+		"browser_page_find":      `{"count": 1, "elements": [{"tagName": "A", "text": "More", "visible": true, "selector": "a"}]}`,
+		"browser_tab_screenshot": "data:image/png;base64,AAAA",
+		"browser_page_click":     "Clicked a",
+	})
+	c := NewClient(ts.URL+"/", "secret")
+	ctx := context.Background()
+
+	tabs, err := c.ListTabs(ctx)
+	if err != nil {
+		t.Fatalf("ListTabs: %v", err)
+	}
+	wantTabs := []Tab{{ID: 1, URL: "https://example.com", Active: true, MutedInfo: &MutedInfo{Muted: true, Reason: "user"}}}
+	if !reflect.DeepEqual(tabs, wantTabs) {
+		t.Errorf("ListTabs = %+v, want %+v", tabs, wantTabs)
+	}
+
+	content, err := c.GetPageContent(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetPageContent: %v", err)
+	}
+	wantContent := &PageContent{Title: "Example", URL: "https://example.com", Links: []Link{{Text: "More", Href: "https://example.com/more"}}}
+	if !reflect.DeepEqual(content, wantContent) {
+		t.Errorf("GetPageContent = %+v, want %+v", content, wantContent)
+	}
+
+	found, err := c.FindElements(ctx, 1, "a")
+	if err != nil {
+		t.Fatalf("FindElements: %v", err)
+	}
+	wantFound := &FindResult{Count: 1, Elements: []ElementInfo{{TagName: "A", Text: "More", Visible: true, Selector: "a"}}}
+	if !reflect.DeepEqual(found, wantFound) {
+		t.Errorf("FindElements = %+v, want %+v", found, wantFound)
+	}
+
+	shot, err := c.ScreenshotTab(ctx, 1)
+	if err != nil || shot != "data:image/png;base64,AAAA" {
+		t.Errorf("ScreenshotTab = %q, %v", shot, err)
+	}
+
+	if err := c.ClickElement(ctx, 1, "a"); err != nil {
+		t.Fatalf("ClickElement: %v", err)
+	}
+	wantArgs := map[string]any{"tabId": float64(1), "selector": "a"}
+	if !reflect.DeepEqual(calls["browser_page_click"], wantArgs) {
+		t.Errorf("browser_page_click args = %v, want %v", calls["browser_page_click"], wantArgs)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	ts, _ := toolServer(t, "secret", map[string]string{"browser_tabs_list": "[]"})
+
+	_, err := NewClient(ts.URL, "wrong").ListTabs(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Errorf("bad token: err = %v, want unauthorized", err)
+	}
+
+	err = NewClient(ts.URL, "secret").CloseTab(context.Background(), 1)
+	if err == nil || !strings.Contains(err.Error(), "Unknown tool: browser_tab_close") {
+		t.Errorf("failed tool: err = %v, want the server's error", err)
+	}
+}
@@ -0,0 +1,54 @@
+// Package client defines the results returned by client calls.
+package client
+
+// Tab represents a browser tab.
+type Tab struct {
+	ID       int    `json:"id"`
+	WindowID int    `json:"windowId"`
+	Index    int    `json:"index"`
+	URL      string `json:"url"`
+	Title    string `json:"title"`
+	Active   bool   `json:"active"`
+	Pinned   bool   `json:"pinned"`
+	Audible  bool   `json:"audible"`
+	Status   string `json:"status"`
+
+	MutedInfo *MutedInfo `json:"mutedInfo,omitempty"`
+}
+
+// MutedInfo describes a tab's muted state.
+// Reason is "user", "capture" or "extension" when the tab is muted.
+type MutedInfo struct {
+	Muted       bool   `json:"muted"`
+	Reason      string `json:"reason,omitempty"`
+	ExtensionID string `json:"extensionId,omitempty"`
+}
+
+// PageContent represents extracted page content.
+type PageContent struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+	Text  string `json:"text"`
+	HTML  string `json:"html"`
+	Links []Link `json:"links"`
+}
+
+// Link represents a page link.
+type Link struct {
+	Text string `json:"text"`
+	Href string `json:"href"`
+}
+
+// ElementInfo represents information about a found element.
+type ElementInfo struct {
+	TagName  string `json:"tagName"`
+	Text     string `json:"text"`
+	Visible  bool   `json:"visible"`
+	Selector string `json:"selector"`
+}
+
+// FindResult represents the result of finding elements.
+type FindResult struct {
+	Count    int           `json:"count"`
+	Elements []ElementInfo `json:"elements"`
+}
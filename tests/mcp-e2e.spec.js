@@ -142,11 +142,11 @@ test.describe('Browser MCP Bridge - E2E Tests', () => {
       expect(result.result.serverInfo.name).toBe('browser-mcp');
     });
 
-    test('MCP tools/list returns all 11 tools', async () => {
+    test('MCP tools/list returns all 118 tools', async () => {
       const result = await mcpCall('tools/list', {});
       
       expect(result.jsonrpc).toBe('2.0');
-      expect(result.result.tools).toHaveLength(11);
+      expect(result.result.tools).toHaveLength(118);
       
       const toolNames = result.result.tools.map(t => t.name);
       expect(toolNames).toContain('browser_tabs_list');
@@ -160,6 +160,113 @@ test.describe('Browser MCP Bridge - E2E Tests', () => {
       expect(toolNames).toContain('browser_page_find');
       expect(toolNames).toContain('browser_tab_activate');
       expect(toolNames).toContain('browser_tab_close');
+      expect(toolNames).toContain('browser_page_accessibility_tree');
+      expect(toolNames).toContain('browser_page_evaluate_xpath');
+      expect(toolNames).toContain('browser_page_observe_mutations');
+      expect(toolNames).toContain('browser_downloads_list');
+      expect(toolNames).toContain('browser_downloads_open');
+      expect(toolNames).toContain('browser_downloads_cancel');
+      expect(toolNames).toContain('browser_downloads_erase');
+      expect(toolNames).toContain('browser_page_get_bounding_rect');
+      expect(toolNames).toContain('browser_tabs_batch');
+      expect(toolNames).toContain('browser_page_scroll_to_element');
+      expect(toolNames).toContain('browser_page_check_checkbox');
+      expect(toolNames).toContain('browser_page_uncheck_checkbox');
+      expect(toolNames).toContain('browser_page_focus');
+      expect(toolNames).toContain('browser_page_blur');
+      expect(toolNames).toContain('browser_page_alert_handle');
+      expect(toolNames).toContain('browser_page_get_pending_dialog');
+      expect(toolNames).toContain('browser_bookmarks_list');
+      expect(toolNames).toContain('browser_bookmarks_create');
+      expect(toolNames).toContain('browser_bookmarks_delete');
+      expect(toolNames).toContain('browser_page_iframe_list');
+      expect(toolNames).toContain('browser_page_switch_to_iframe');
+      expect(toolNames).toContain('browser_page_get_console_logs');
+      expect(toolNames).toContain('browser_page_wait_for_navigation');
+      expect(toolNames).toContain('browser_page_get_meta_tags');
+      expect(toolNames).toContain('browser_page_get_og_tags');
+      expect(toolNames).toContain('browser_page_get_network_requests');
+      expect(toolNames).toContain('browser_tab_mute');
+      expect(toolNames).toContain('browser_tab_unmute');
+      expect(toolNames).toContain('browser_page_pdf');
+      expect(toolNames).toContain('browser_page_iframe_execute_script');
+      expect(toolNames).toContain('browser_page_highlight_elements');
+      expect(toolNames).toContain('browser_page_get_element_by_label');
+      expect(toolNames).toContain('browser_page_fill_by_label');
+      expect(toolNames).toContain('browser_page_emulate_geolocation');
+      expect(toolNames).toContain('browser_page_clear_geolocation');
+      expect(toolNames).toContain('browser_page_emulate_device');
+      expect(toolNames).toContain('browser_page_emulate_device_preset');
+      expect(toolNames).toContain('browser_page_get_page_errors');
+      expect(toolNames).toContain('browser_page_intercept_response');
+      expect(toolNames).toContain('browser_page_get_title');
+      expect(toolNames).toContain('browser_page_get_url');
+      expect(toolNames).toContain('browser_page_content_markdown');
+      expect(toolNames).toContain('browser_extension_info');
+      expect(toolNames).toContain('browser_page_run_snippet');
+      expect(toolNames).toContain('browser_tabs_filter');
+      expect(toolNames).toContain('browser_page_get_structured_data');
+      expect(toolNames).toContain('browser_page_screenshot_element');
+      expect(toolNames).toContain('browser_page_emulate_timezone');
+      expect(toolNames).toContain('browser_page_clear_input');
+      expect(toolNames).toContain('browser_page_get_page_source');
+      expect(toolNames).toContain('browser_page_wait_for_url');
+      expect(toolNames).toContain('browser_page_list_storage_keys');
+      expect(toolNames).toContain('browser_page_clear_storage');
+      expect(toolNames).toContain('browser_tab_reload');
+      expect(toolNames).toContain('browser_page_get_selection');
+      expect(toolNames).toContain('browser_page_set_selection');
+      expect(toolNames).toContain('browser_page_click_at_coordinates');
+      expect(toolNames).toContain('browser_page_get_element_text');
+      expect(toolNames).toContain('browser_page_get_element_html');
+      expect(toolNames).toContain('browser_page_wait_for_text');
+      expect(toolNames).toContain('browser_page_get_scroll_position');
+      expect(toolNames).toContain('browser_page_get_scroll_size');
+      expect(toolNames).toContain('browser_page_emulate_media');
+      expect(toolNames).toContain('browser_page_count_elements');
+      expect(toolNames).toContain('browser_page_element_exists');
+      expect(toolNames).toContain('browser_page_get_form_fields');
+      expect(toolNames).toContain('browser_page_scroll_by');
+      expect(toolNames).toContain('browser_page_scroll_element_by');
+      expect(toolNames).toContain('browser_page_get_links');
+      expect(toolNames).toContain('browser_page_get_images');
+      expect(toolNames).toContain('browser_page_trigger_event');
+      expect(toolNames).toContain('browser_page_get_table_data');
+      expect(toolNames).toContain('browser_page_get_dropdown_options');
+      expect(toolNames).toContain('browser_page_get_selected_option');
+      expect(toolNames).toContain('browser_tab_move');
+      expect(toolNames).toContain('browser_tab_move_to_new_window');
+      expect(toolNames).toContain('browser_tab_focus_and_navigate');
+      expect(toolNames).toContain('browser_page_get_video_info');
+      expect(toolNames).toContain('browser_page_control_video');
+      expect(toolNames).toContain('browser_page_execute_async');
+      expect(toolNames).toContain('browser_page_get_performance_metrics');
+      expect(toolNames).toContain('browser_page_right_click');
+      expect(toolNames).toContain('browser_page_double_click');
+      expect(toolNames).toContain('browser_page_get_canvas_data');
+      expect(toolNames).toContain('browser_page_emulate_dark_mode');
+      expect(toolNames).toContain('browser_tabs_close_multiple');
+      expect(toolNames).toContain('browser_tabs_close_others');
+      expect(toolNames).toContain('browser_page_get_anchor_by_text');
+      expect(toolNames).toContain('browser_page_click_anchor_by_text');
+      expect(toolNames).toContain('browser_page_set_attribute');
+      expect(toolNames).toContain('browser_page_remove_attribute');
+      expect(toolNames).toContain('browser_page_get_all_text_nodes');
+      expect(toolNames).toContain('browser_page_get_visible_text');
+      expect(toolNames).toContain('browser_page_run_lighthouse');
+      expect(toolNames).toContain('browser_page_get_color_at');
+      expect(toolNames).toContain('browser_page_get_shadow_dom');
+      expect(toolNames).toContain('browser_page_query_shadow_dom');
+      expect(toolNames).toContain('browser_page_mock_geolocation_track');
+      expect(toolNames).toContain('browser_page_execute_in_worker');
+      expect(toolNames).toContain('browser_page_get_robots_txt');
+      expect(toolNames).toContain('browser_page_check_robots_allowed');
+      expect(toolNames).toContain('browser_page_get_sitemap');
+      expect(toolNames).toContain('browser_page_diff_screenshots');
+      expect(toolNames).toContain('browser_page_get_web_workers');
+      expect(toolNames).toContain('browser_page_get_response_headers');
+      expect(toolNames).toContain('browser_page_wait_for_network_idle');
+      expect(toolNames).toContain('browser_page_get_storage_quota');
     });
   });
 
@@ -267,6 +374,33 @@ test.describe('Browser MCP Bridge - E2E Tests', () => {
         expect(finalTabs.length).toBe(initialTabs.length - 1);
       }
     });
+
+    test('browser_tab_reload forwards bypassCache', async ({ extContext: context }) => {
+      const newPage = await context.newPage();
+      await newPage.goto('https://example.com');
+      await newPage.waitForTimeout(1000);
+      
+      const listResult = await callTool('browser_tabs_list');
+      const tabs = JSON.parse(getResultText(listResult));
+      const tab = tabs.find(t => t.url.includes('example.com'));
+      expect(tab).toBeTruthy();
+      
+      // A cache-bypassing reload sends Cache-Control: no-cache
+      const requestPromise = newPage.waitForRequest(req => req.url().includes('example.com') && req.isNavigationRequest());
+      const result = await callTool('browser_tab_reload', {
+        tabId: tab.id,
+        bypassCache: true
+      });
+      
+      expect(result.jsonrpc).toBe('2.0');
+      expect(getResultText(result)).toContain('reloading');
+      
+      const request = await requestPromise;
+      const headers = await request.allHeaders();
+      expect(headers['cache-control']).toBe('no-cache');
+      
+      await newPage.close();
+    });
   });
 
   test.describe('Page Interaction Tools', () => {
@@ -323,6 +457,31 @@ test.describe('Browser MCP Bridge - E2E Tests', () => {
       expect(typeof findResult.count).toBe('number');
     });
 
+    test('browser_page_run_lighthouse returns category scores', async ({ extContext: context }) => {
+      const page = context.pages()[0] || await context.newPage();
+      
+      await page.goto('https://example.com');
+      await page.waitForTimeout(1000);
+      
+      const listResult = await callTool('browser_tabs_list');
+      const tabs = JSON.parse(getResultText(listResult));
+      const activeTab = tabs.find(t => t.active);
+      
+      const result = await callTool('browser_page_run_lighthouse', {
+        tabId: activeTab.id,
+        categories: ['accessibility', 'seo']
+      });
+      
+      expect(result.jsonrpc).toBe('2.0');
+      
+      const audit = JSON.parse(getResultText(result));
+      expect(audit.accessibility).toBeGreaterThan(0);
+      expect(audit.accessibility).toBeLessThanOrEqual(100);
+      expect(audit.seo).toBeGreaterThan(0);
+      expect(audit.performance).toBe(0);
+      expect(audit.diagnostics.audits.seo).toHaveProperty('document-title');
+    });
+
     test.skip('browser_page_click clicks elements', async ({ extContext: context }) => {
       // SKIPPED: Requires page without CSP or special permissions
       // CSP on most websites blocks the extension's executeScript approach
@@ -564,7 +723,7 @@ test.describe('Browser MCP Bridge - E2E Tests', () => {
       const result = await mcpCall('tools/list', {});
       const toolNames = result.result.tools.map(t => t.name);
       
-      const expectedPrefixes = ['browser_tabs_', 'browser_tab_', 'browser_page_'];
+      const expectedPrefixes = ['browser_tabs_', 'browser_tab_', 'browser_page_', 'browser_downloads_', 'browser_bookmarks_', 'browser_extension_'];
       
       for (const name of toolNames) {
         const hasValidPrefix = expectedPrefixes.some(prefix => name.startsWith(prefix));